@@ -0,0 +1,54 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+
+	"github.com/wrutkowski/go1010/arena"
+	"github.com/wrutkowski/go1010/game"
+)
+
+func main() {
+	players := flag.String("players", "random,greedy,linear", "comma separated players: random, greedy, linear, search, expectimax, mcts or neural:FILE")
+	games := flag.Int("games", 100, "number of games played by each player")
+	seed := flag.Int64("seed", 1, "seed of the first game, next games use consecutive seeds")
+	workers := flag.Int("workers", runtime.NumCPU(), "number of games played at once")
+	extensions := flag.Bool("extensions", false, "enable hold slot, rotation and 3 rerolls")
+	moveLimit := flag.Int("moves", 0, "end each game after a given number of placed blocks")
+	flag.Parse()
+
+	config := game.DefaultConfig()
+	config.Engine = game.BitboardEngine
+	if *extensions {
+		config.Hold = true
+		config.Rotation = true
+		config.Rerolls = 3
+	}
+	config.MoveLimit = *moveLimit
+
+	var entrants []arena.Entrant
+	for _, name := range strings.Split(*players, ",") {
+		entrant, err := arena.NewEntrant(strings.TrimSpace(name), config)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		entrants = append(entrants, entrant)
+	}
+
+	fmt.Printf("Playing %d games with each of %d players...\n", *games, len(entrants))
+	results := arena.Arena{
+		Entrants: entrants,
+		Seeds:    arena.Seeds(*seed, *games),
+		Workers:  *workers,
+		Options:  []game.Option{game.WithConfig(config)},
+	}.Run()
+
+	if err := results.Report(os.Stdout); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,103 @@
+package arena
+
+import (
+	"runtime"
+	"sync"
+
+	"github.com/wrutkowski/go1010/bot"
+	"github.com/wrutkowski/go1010/game"
+)
+
+// Entrant is the player taking part in the arena
+type Entrant struct {
+	Name string
+	// New returns the player of a single game with a given seed. It is
+	// called for every game, so players keeping state, like the neural
+	// network or the random generator, are not shared between goroutines.
+	New func(seed int64) bot.Player
+}
+
+// Arena plays games of every entrant with the same seeds, so that all
+// entrants get identical sequences of blocks
+type Arena struct {
+	Entrants []Entrant
+	Seeds    []int64
+	// Workers is the number of games played at once, number of CPUs is used
+	// when it is 0
+	Workers int
+	// Options configure every game, seed of the game is added to them
+	Options []game.Option
+}
+
+// Result of a single game
+type Result struct {
+	Seed  int64
+	Score int
+	// Moves is the number of blocks placed before the game was over
+	Moves int
+	// Invalid is true when the game ended with a move which is not possible
+	// or was stopped after bot.MaxActions moves without a placement
+	Invalid bool
+}
+
+// Seeds returns count of consecutive seeds starting with first
+func Seeds(first int64, count int) []int64 {
+	seeds := make([]int64, count)
+	for i := range seeds {
+		seeds[i] = first + int64(i)
+	}
+	return seeds
+}
+
+// Run plays all games and returns their results
+func (arena Arena) Run() Results {
+	results := Results{
+		Names: make([]string, len(arena.Entrants)),
+		Games: make([][]Result, len(arena.Entrants)),
+	}
+	for i, entrant := range arena.Entrants {
+		results.Names[i] = entrant.Name
+		results.Games[i] = make([]Result, len(arena.Seeds))
+	}
+
+	workers := arena.Workers
+	if workers < 1 {
+		workers = runtime.NumCPU()
+	}
+	type task struct {
+		entrant int
+		seed    int
+	}
+	tasks := make(chan task)
+	var wait sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wait.Add(1)
+		go func() {
+			defer wait.Done()
+			for task := range tasks {
+				results.Games[task.entrant][task.seed] = arena.play(arena.Entrants[task.entrant], arena.Seeds[task.seed])
+			}
+		}()
+	}
+	for seed := range arena.Seeds {
+		for entrant := range arena.Entrants {
+			tasks <- task{entrant, seed}
+		}
+	}
+	close(tasks)
+	wait.Wait()
+
+	return results
+}
+
+// play plays a single game of the entrant
+func (arena Arena) play(entrant Entrant, seed int64) Result {
+	options := append(arena.Options[:len(arena.Options):len(arena.Options)], game.WithSeed(seed))
+	final, err := bot.Play(entrant.New(seed), game.NewWithOptions(options...))
+	return Result{
+		Seed:    seed,
+		Score:   final.Score,
+		Moves:   final.MovesMade(),
+		Invalid: game.IsInvalidMove(err),
+	}
+}
@@ -0,0 +1,72 @@
+package arena
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wrutkowski/go1010/bot"
+	"github.com/wrutkowski/go1010/game"
+)
+
+func TestArenaRun(t *testing.T) {
+	assert := assert.New(t)
+
+	config := game.DefaultConfig()
+	config.MoveLimit = 20
+	arena := Arena{
+		Entrants: []Entrant{
+			{"random", func(seed int64) bot.Player { return bot.NewRandom(seed) }},
+			{"greedy", func(seed int64) bot.Player { return bot.NewGreedy() }},
+		},
+		Seeds:   Seeds(1, 4),
+		Workers: 3,
+		Options: []game.Option{game.WithConfig(config)},
+	}
+	results := arena.Run()
+
+	assert.Equal([]string{"random", "greedy"}, results.Names)
+	assert.Equal(4, len(results.Games[1]))
+	for i, result := range results.Games[1] {
+		assert.Equal(int64(i+1), result.Seed)
+		assert.Equal(20, result.Moves)
+		assert.False(result.Invalid)
+
+		final, _ := bot.Play(bot.NewGreedy(), game.NewWithOptions(game.WithConfig(config), game.WithSeed(result.Seed)))
+		assert.Equal(final.Score, result.Score, "games are played with seeds of the arena")
+	}
+
+	arena.Workers = 1
+	assert.Equal(results, arena.Run(), "results do not depend on the number of workers")
+}
+
+func TestArenaCyclingPlayer(t *testing.T) {
+	assert := assert.New(t)
+
+	config := game.DefaultConfig()
+	config.Hold = true
+	config.Rotation = true
+	config.Rerolls = 3
+	cycling := bot.PlayerFunc(func(g game.Game) game.Move {
+		return game.Move{Block: game.A, Action: game.HoldBlock}
+	})
+	results := Arena{
+		Entrants: []Entrant{{"cycling", func(seed int64) bot.Player { return cycling }}},
+		Seeds:    Seeds(1, 2),
+		Options:  []game.Option{game.WithConfig(config)},
+	}.Run()
+
+	for _, result := range results.Games[0] {
+		assert.True(result.Invalid, "player which never places a block is stopped")
+		assert.Equal(0, result.Moves)
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(0.0, Percentile(nil, 50))
+	assert.Equal(5.0, Percentile([]int{5}, 90))
+	assert.Equal(25.0, Percentile([]int{10, 20, 30, 40}, 50))
+	assert.Equal(13.0, Percentile([]int{10, 20, 30, 40}, 10))
+	assert.Equal(40.0, Percentile([]int{10, 20, 30, 40}, 100))
+}
@@ -0,0 +1,59 @@
+package arena
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/wrutkowski/go1010/bot"
+	"github.com/wrutkowski/go1010/eval"
+	"github.com/wrutkowski/go1010/game"
+	"github.com/wrutkowski/go1010/neural"
+)
+
+// NewEntrant returns entrant of a given name: random, greedy, linear, search,
+// expectimax, mcts or neural:FILE. Neural network is loaded from the file and
+// must match inputs and outputs of games with a given config.
+func NewEntrant(name string, config game.Config) (Entrant, error) {
+	entrant := Entrant{Name: name}
+	switch name {
+	case "random":
+		entrant.New = func(seed int64) bot.Player { return bot.NewRandom(seed) }
+	case "greedy":
+		entrant.New = func(seed int64) bot.Player { return bot.NewGreedy() }
+	case "linear":
+		entrant.New = func(seed int64) bot.Player { return eval.DefaultLinear() }
+	case "search":
+		// exhaustive search takes seconds per move, placements are limited
+		entrant.New = func(seed int64) bot.Player { return bot.Search{Weights: bot.DefaultWeights(), Width: 8} }
+	case "expectimax":
+		entrant.New = func(seed int64) bot.Player {
+			player := bot.NewExpectimax()
+			player.Seed = seed
+			return player
+		}
+	case "mcts":
+		entrant.New = func(seed int64) bot.Player {
+			player := bot.NewMCTS()
+			player.Seed = seed
+			return player
+		}
+	default:
+		if !strings.HasPrefix(name, "neural:") {
+			return entrant, fmt.Errorf("Unknown player: %s", name)
+		}
+		network, err := neural.LoadNetwork(strings.TrimPrefix(name, "neural:"))
+		if err != nil {
+			return entrant, fmt.Errorf("Error while loading %s. %v", name, err)
+		}
+		if len(network.Layers()) < 3 {
+			return entrant, fmt.Errorf("Network %s has no hidden layer", name)
+		}
+		inputs := len(bot.NeuralInput(game.NewWithOptions(game.WithConfig(config))))
+		if layers := network.Layers(); layers[0] != inputs || layers[len(layers)-1] != bot.NeuralOutputs(config) {
+			return entrant, fmt.Errorf("Network %s does not match the game, it has %d inputs and %d outputs", name, layers[0], layers[len(layers)-1])
+		}
+		// each game runs its own copy of the network
+		entrant.New = func(seed int64) bot.Player { return bot.Neural{Network: network.Clone()} }
+	}
+	return entrant, nil
+}
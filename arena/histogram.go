@@ -0,0 +1,67 @@
+package arena
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Bin of the histogram counts scores from From to To, both included
+type Bin struct {
+	From  int
+	To    int
+	Count int
+}
+
+// Histogram splits range of scores into at most a given number of bins of
+// equal width and counts scores falling into each of them
+func Histogram(scores []int, bins int) []Bin {
+	if len(scores) == 0 || bins < 1 {
+		return nil
+	}
+	min, max := scores[0], scores[0]
+	for _, score := range scores {
+		if score < min {
+			min = score
+		}
+		if score > max {
+			max = score
+		}
+	}
+
+	width := (max - min + bins) / bins
+	histogram := make([]Bin, (max-min)/width+1)
+	for i := range histogram {
+		histogram[i].From = min + i*width
+		histogram[i].To = histogram[i].From + width - 1
+	}
+	for _, score := range scores {
+		histogram[(score-min)/width].Count++
+	}
+	return histogram
+}
+
+// WriteHistogram writes bins as horizontal bars, the bar of the most
+// numerous bin is a given number of characters wide
+func WriteHistogram(w io.Writer, histogram []Bin, width int) error {
+	most := 0
+	labelWidth := 0
+	for _, bin := range histogram {
+		if bin.Count > most {
+			most = bin.Count
+		}
+		if length := len(fmt.Sprint(bin.To)); length > labelWidth {
+			labelWidth = length
+		}
+	}
+	for _, bin := range histogram {
+		bar := 0
+		if most > 0 {
+			bar = bin.Count * width / most
+		}
+		if _, err := fmt.Fprintf(w, "%*d-%-*d |%s %d\n", labelWidth, bin.From, labelWidth, bin.To, strings.Repeat("█", bar), bin.Count); err != nil {
+			return err
+		}
+	}
+	return nil
+}
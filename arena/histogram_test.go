@@ -0,0 +1,30 @@
+package arena
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHistogram(t *testing.T) {
+	assert := assert.New(t)
+
+	histogram := Histogram([]int{0, 5, 9, 10, 19, 20, 20}, 3)
+	assert.Equal([]Bin{{0, 6, 2}, {7, 13, 2}, {14, 20, 3}}, histogram)
+
+	assert.Equal([]Bin{{4, 4, 2}}, Histogram([]int{4, 4}, 10), "all scores are equal")
+	assert.Equal([]Bin{{1, 1, 1}, {2, 2, 0}, {3, 3, 1}}, Histogram([]int{1, 3}, 10), "bins are at least 1 wide")
+	assert.Nil(Histogram(nil, 10))
+}
+
+func TestWriteHistogram(t *testing.T) {
+	assert := assert.New(t)
+
+	var buffer bytes.Buffer
+	assert.Nil(WriteHistogram(&buffer, []Bin{{0, 9, 2}, {10, 19, 4}, {20, 29, 0}}, 8))
+	assert.Equal(""+
+		" 0-9  |████ 2\n"+
+		"10-19 |████████ 4\n"+
+		"20-29 | 0\n", buffer.String())
+}
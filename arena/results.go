@@ -0,0 +1,162 @@
+package arena
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"text/tabwriter"
+)
+
+// Results holds results of games played in the arena
+type Results struct {
+	Names []string
+	// Games holds results of each entrant in the order of seeds
+	Games [][]Result
+}
+
+// Stats summarize scores and moves of the entrant
+type Stats struct {
+	Games   int
+	Mean    float64
+	Median  float64
+	P10     float64
+	P90     float64
+	Min     int
+	Max     int
+	Moves   float64
+	Invalid int
+}
+
+// Scores returns scores of the entrant in the order of seeds
+func (results Results) Scores(entrant int) []int {
+	scores := make([]int, len(results.Games[entrant]))
+	for i, result := range results.Games[entrant] {
+		scores[i] = result.Score
+	}
+	return scores
+}
+
+// Stats returns statistics of the games of the entrant
+func (results Results) Stats(entrant int) Stats {
+	games := results.Games[entrant]
+	stats := Stats{Games: len(games)}
+	if len(games) == 0 {
+		return stats
+	}
+
+	scores := results.Scores(entrant)
+	sort.Ints(scores)
+	stats.Min, stats.Max = scores[0], scores[len(scores)-1]
+	stats.Median = Percentile(scores, 50)
+	stats.P10 = Percentile(scores, 10)
+	stats.P90 = Percentile(scores, 90)
+
+	score, moves := 0, 0
+	for _, result := range games {
+		score += result.Score
+		moves += result.Moves
+		if result.Invalid {
+			stats.Invalid++
+		}
+	}
+	stats.Mean = float64(score) / float64(len(games))
+	stats.Moves = float64(moves) / float64(len(games))
+	return stats
+}
+
+// Percentile returns p-th percentile of sorted scores, values between scores
+// are interpolated linearly
+func Percentile(sorted []int, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := p / 100 * float64(len(sorted)-1)
+	lower := int(math.Floor(rank))
+	if lower < 0 {
+		return float64(sorted[0])
+	}
+	if lower >= len(sorted)-1 {
+		return float64(sorted[len(sorted)-1])
+	}
+	fraction := rank - float64(lower)
+	return float64(sorted[lower]) + fraction*float64(sorted[lower+1]-sorted[lower])
+}
+
+// WinRate returns share of the seeds on which entrant a scored more than
+// entrant b, draws count as half a win
+func (results Results) WinRate(a int, b int) float64 {
+	games := len(results.Games[a])
+	if games == 0 {
+		return 0
+	}
+	wins := 0.0
+	for i := 0; i < games; i++ {
+		switch scoreA, scoreB := results.Games[a][i].Score, results.Games[b][i].Score; {
+		case scoreA > scoreB:
+			wins++
+		case scoreA == scoreB:
+			wins += 0.5
+		}
+	}
+	return wins / float64(games)
+}
+
+// Report writes table of statistics of all entrants followed by the table of
+// pairwise win rates, row entrant against column entrant
+func (results Results) Report(w io.Writer) error {
+	table := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(table, "player\tgames\tmean\tmedian\tp10\tp90\tmin\tmax\tmoves\tinvalid\t")
+	for i, name := range results.Names {
+		stats := results.Stats(i)
+		fmt.Fprintf(table, "%s\t%d\t%.1f\t%.1f\t%.1f\t%.1f\t%d\t%d\t%.1f\t%d\t\n", name, stats.Games, stats.Mean, stats.Median, stats.P10, stats.P90, stats.Min, stats.Max, stats.Moves, stats.Invalid)
+	}
+	if err := table.Flush(); err != nil {
+		return err
+	}
+	if len(results.Names) < 2 {
+		return nil
+	}
+
+	fmt.Fprintln(w)
+	fmt.Fprint(table, "win rate\t")
+	for _, name := range results.Names {
+		fmt.Fprintf(table, "%s\t", name)
+	}
+	fmt.Fprintln(table)
+	for a, name := range results.Names {
+		fmt.Fprintf(table, "%s\t", name)
+		for b := range results.Names {
+			if a == b {
+				fmt.Fprint(table, "-\t")
+				continue
+			}
+			fmt.Fprintf(table, "%.0f%%\t", 100*results.WinRate(a, b))
+		}
+		fmt.Fprintln(table)
+	}
+	return table.Flush()
+}
+
+// WriteCSV writes results of all games as CSV with a header, one game in a
+// row: player, seed, score, moves and whether the game ended with an invalid
+// move
+func (results Results) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	writer.Write([]string{"player", "seed", "score", "moves", "invalid"})
+	for i, name := range results.Names {
+		for _, result := range results.Games[i] {
+			writer.Write([]string{
+				name,
+				strconv.FormatInt(result.Seed, 10),
+				strconv.Itoa(result.Score),
+				strconv.Itoa(result.Moves),
+				strconv.FormatBool(result.Invalid),
+			})
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
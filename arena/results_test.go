@@ -0,0 +1,71 @@
+package arena
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var results = Results{
+	Names: []string{"first", "second"},
+	Games: [][]Result{
+		{{Seed: 1, Score: 10, Moves: 4}, {Seed: 2, Score: 30, Moves: 8}, {Seed: 3, Score: 20, Moves: 6, Invalid: true}},
+		{{Seed: 1, Score: 15, Moves: 5}, {Seed: 2, Score: 10, Moves: 4}, {Seed: 3, Score: 20, Moves: 6}},
+	},
+}
+
+func TestResultsStats(t *testing.T) {
+	assert := assert.New(t)
+
+	stats := results.Stats(0)
+	assert.Equal(3, stats.Games)
+	assert.Equal(20.0, stats.Mean)
+	assert.Equal(20.0, stats.Median)
+	assert.Equal(12.0, stats.P10)
+	assert.Equal(28.0, stats.P90)
+	assert.Equal(10, stats.Min)
+	assert.Equal(30, stats.Max)
+	assert.Equal(6.0, stats.Moves)
+	assert.Equal(1, stats.Invalid)
+
+	assert.Equal([]int{10, 30, 20}, results.Scores(0), "scores are in the order of seeds")
+	assert.Equal(Stats{}, Results{Names: []string{"none"}, Games: [][]Result{nil}}.Stats(0))
+}
+
+func TestResultsWinRate(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(0.5, results.WinRate(0, 1), "one win, one loss and a draw")
+	assert.Equal(0.5, results.WinRate(1, 0))
+}
+
+func TestResultsReport(t *testing.T) {
+	assert := assert.New(t)
+
+	var buffer bytes.Buffer
+	assert.Nil(results.Report(&buffer))
+	assert.Equal(""+
+		"  player  games  mean  median   p10   p90  min  max  moves  invalid\n"+
+		"   first      3  20.0    20.0  12.0  28.0   10   30    6.0        1\n"+
+		"  second      3  15.0    15.0  11.0  19.0   10   20    5.0        0\n"+
+		"\n"+
+		"  win rate  first  second\n"+
+		"     first      -     50%\n"+
+		"    second    50%       -\n", buffer.String())
+}
+
+func TestResultsWriteCSV(t *testing.T) {
+	assert := assert.New(t)
+
+	var buffer bytes.Buffer
+	assert.Nil(results.WriteCSV(&buffer))
+	assert.Equal(""+
+		"player,seed,score,moves,invalid\n"+
+		"first,1,10,4,false\n"+
+		"first,2,30,8,false\n"+
+		"first,3,20,6,true\n"+
+		"second,1,15,5,false\n"+
+		"second,2,10,4,false\n"+
+		"second,3,20,6,false\n", buffer.String())
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+
+	"github.com/wrutkowski/go1010/arena"
+	"github.com/wrutkowski/go1010/game"
+)
+
+func main() {
+	players := flag.String("players", "random", "comma separated players: random, greedy, linear, search, expectimax, mcts or neural:FILE")
+	games := flag.Int("games", 1000, "number of games played by each player")
+	seed := flag.Int64("seed", 1, "seed of the first game, next games use consecutive seeds")
+	workers := flag.Int("workers", runtime.NumCPU(), "number of games played at once")
+	bins := flag.Int("bins", 20, "number of bins of the score histogram")
+	csvFile := flag.String("csv", "", "write score of every game to CSV file with a given name")
+	flag.Parse()
+
+	// games are configured like the training in main.go, so that scores can
+	// be compared with the fitness of networks
+	config := game.DefaultConfig()
+	config.Engine = game.BitboardEngine
+
+	var entrants []arena.Entrant
+	for _, name := range strings.Split(*players, ",") {
+		entrant, err := arena.NewEntrant(strings.TrimSpace(name), config)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		entrants = append(entrants, entrant)
+	}
+
+	fmt.Printf("Playing %d games with each of %d players...\n", *games, len(entrants))
+	results := arena.Arena{
+		Entrants: entrants,
+		Seeds:    arena.Seeds(*seed, *games),
+		Workers:  *workers,
+		Options:  []game.Option{game.WithConfig(config)},
+	}.Run()
+
+	if err := results.Report(os.Stdout); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	for i, name := range results.Names {
+		fmt.Printf("\nScores of %s:\n", name)
+		arena.WriteHistogram(os.Stdout, arena.Histogram(results.Scores(i), *bins), 50)
+	}
+
+	if *csvFile != "" {
+		file, err := os.Create(*csvFile)
+		if err != nil {
+			fmt.Println("Error while saving.", err)
+			os.Exit(1)
+		}
+		err = results.WriteCSV(file)
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			fmt.Println("Error while saving.", err)
+			os.Exit(1)
+		}
+	}
+}
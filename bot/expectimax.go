@@ -0,0 +1,243 @@
+package bot
+
+import (
+	"math/rand"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/wrutkowski/go1010/game"
+)
+
+// Expectimax is the Player looking beyond the current deal. Plans of the
+// deal are compared by the expected value of the best plan of the next
+// deal, blocks of the next deal being drawn uniformly from the catalogue of
+// the game, down to Depth deals. Number of possible deals grows quickly, so
+// the expectation is estimated from Samples random deals and values of
+// boards already evaluated are cached.
+type Expectimax struct {
+	Weights Weights
+	// Depth is the number of next deals looked at, with 0 the player
+	// chooses the same plan as Search
+	Depth int
+	// Samples is the number of random deals used to estimate the expected
+	// value of the board
+	Samples int
+	// Width limits placements of each block like in Search and the number
+	// of the best plans of each deal looked beyond, 0 means no limit
+	Width int
+	// Budget limits the time of choosing a move and Nodes the number of
+	// deals evaluated, boards beyond the budget are valued by Weights
+	// alone. 0 means no limit.
+	Budget time.Duration
+	Nodes  int
+	// Seed of the generator drawing deals, the same seed gives the same
+	// moves when the budget is not limited by time
+	Seed int64
+}
+
+// NewExpectimax returns Expectimax looking one deal ahead, estimated from 8
+// samples, using DefaultWeights
+func NewExpectimax() Expectimax {
+	return Expectimax{
+		Weights: DefaultWeights(),
+		Depth:   1,
+		Samples: 8,
+		Width:   3,
+		Seed:    1,
+	}
+}
+
+// gameOverValue is the value of the deal where every plan ends the game
+const gameOverValue = -1e6
+
+// ChooseMove returns the first move of the plan with the best expected
+// value, only Width best plans of the deal are looked beyond. When every plan
+// ends the game the first legal move or action is returned.
+func (player Expectimax) ChooseMove(g game.Game) game.Move {
+	e := player.newExpectimax()
+	var best []game.Move
+	bestValue := gameOverValue
+	for _, outcome := range e.outcomes(g) {
+		value := player.Weights.Lines*float64(outcome.lines) + e.value(outcome.next, player.Depth)
+		if best == nil || value > bestValue {
+			best, bestValue = outcome.plan, value
+		}
+	}
+	if len(best) > 0 {
+		return best[0]
+	}
+	return fallbackMove(g)
+}
+
+// Candidates returns all legal first moves valued by the expected value of
+// the plan starting with the move. Plans of each move are compared by Weights
+// and only the best one is looked beyond, so that all moves can be compared
+// in a reasonable time. Moves of which every plan ends the game are not
+// included.
+func (player Expectimax) Candidates(g game.Game) []Candidate {
+	e := player.newExpectimax()
+	var candidates []Candidate
+	for _, move := range g.LegalMoves() {
+		next, result, err := g.Preview(move)
+		if err != nil {
+			continue
+		}
+		lines := result.LinesCleared()
+
+		// the rest of the deal is planned like in ChooseMove, game ended by
+		// the move limit is finished like after the deal
+		outcomes := []outcome{{next: next}}
+		movesLeft, limited := next.MovesLeft()
+		if result.GameOver && !(limited && movesLeft == 0) {
+			continue
+		}
+		if !result.BlocksDealt && !result.GameOver {
+			outcomes = e.outcomes(next)
+		}
+
+		if len(outcomes) == 0 {
+			continue
+		}
+		best := outcomes[0]
+		value := player.Weights.Lines*float64(lines+best.lines) + e.value(best.next, player.Depth)
+		candidates = append(candidates, Candidate{move, value})
+	}
+	SortCandidates(candidates)
+	return candidates
+}
+
+// newExpectimax returns state of a single choice of the move
+func (player Expectimax) newExpectimax() *expectimax {
+	return &expectimax{
+		Expectimax: player,
+		search:     Search{Weights: player.Weights, Width: player.Width},
+		random:     rand.New(rand.NewSource(player.Seed)),
+		values:     make(map[string]float64),
+		start:      time.Now(),
+	}
+}
+
+// expectimax holds state of a single choice of the move
+type expectimax struct {
+	Expectimax
+	search Search
+	random *rand.Rand
+	values map[string]float64
+	nodes  int
+	start  time.Time
+}
+
+// outcome is the game after the plan of the deal
+type outcome struct {
+	plan  []game.Move
+	next  game.Game
+	lines int
+	value float64
+}
+
+// outcomes returns plans of the deal leading to different boards, only
+// Width best ones by Weights when the width is limited
+func (e *expectimax) outcomes(g game.Game) []outcome {
+	var outcomes []outcome
+	seen := make(map[string]int)
+	e.search.plans(g, func(plan []game.Move, next game.Game, lines int) {
+		value := e.Weights.evaluate(measure(next.Board, lines))
+		key := boardKey(next.Board)
+		if i, ok := seen[key]; ok {
+			if value > outcomes[i].value {
+				outcomes[i] = outcome{plan, next, lines, value}
+			}
+			return
+		}
+		seen[key] = len(outcomes)
+		outcomes = append(outcomes, outcome{plan, next, lines, value})
+	})
+
+	sort.SliceStable(outcomes, func(i, j int) bool {
+		return outcomes[i].value > outcomes[j].value
+	})
+	if e.Width > 0 && len(outcomes) > e.Width {
+		outcomes = outcomes[:e.Width]
+	}
+	return outcomes
+}
+
+// value returns the expected value of the board of the game looking depth
+// deals ahead. Points for lanes removed before the board was reached are not
+// included.
+func (e *expectimax) value(g game.Game, depth int) float64 {
+	if depth == 0 || e.budgetExceeded() {
+		return e.Weights.evaluate(measure(g.Board, 0))
+	}
+
+	key := boardKey(g.Board) + " " + strconv.Itoa(depth)
+	if value, ok := e.values[key]; ok {
+		return value
+	}
+
+	samples := e.Samples
+	if samples <= 0 {
+		samples = 1
+	}
+	total := 0.0
+	for i := 0; i < samples; i++ {
+		total += e.dealValue(dealRandom(g, e.random), depth)
+	}
+	value := total / float64(samples)
+	e.values[key] = value
+	return value
+}
+
+// dealValue returns value of the best plan of the dealt blocks
+func (e *expectimax) dealValue(g game.Game, depth int) float64 {
+	e.nodes++
+	best := gameOverValue
+	for _, outcome := range e.outcomes(g) {
+		value := e.Weights.Lines*float64(outcome.lines) + e.value(outcome.next, depth-1)
+		if value > best {
+			best = value
+		}
+	}
+	return best
+}
+
+// dealRandom returns copy of the game with blocks drawn uniformly from the
+// catalogue of the game. Board is shared with the game, it is not modified
+// by moves made with game.Preview.
+func dealRandom(g game.Game, random *rand.Rand) game.Game {
+	shapes := g.Shapes()
+	g.Blocks = make([][][]game.BoardElement, len(g.Blocks))
+	for i := range g.Blocks {
+		shape, _ := shapes.Shape(random.Intn(shapes.Len()))
+		g.Blocks[i] = shape.Grid()
+	}
+	return g
+}
+
+func (e *expectimax) budgetExceeded() bool {
+	if e.Nodes > 0 && e.nodes >= e.Nodes {
+		return true
+	}
+	return e.Budget > 0 && time.Since(e.start) >= e.Budget
+}
+
+// boardKey returns text identifying filled cells and stones of the board,
+// colors do not matter
+func boardKey(board [][]game.BoardElement) string {
+	key := make([]byte, 0, len(board)*(len(board[0])+1))
+	for _, row := range board {
+		for _, element := range row {
+			switch element {
+			case game.None:
+				key = append(key, '.')
+			case game.Stone:
+				key = append(key, 's')
+			default:
+				key = append(key, '#')
+			}
+		}
+		key = append(key, '/')
+	}
+	return string(key)
+}
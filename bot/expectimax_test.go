@@ -0,0 +1,82 @@
+package bot
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wrutkowski/go1010/game"
+)
+
+func TestExpectimaxChooseMove(t *testing.T) {
+	assert := assert.New(t)
+
+	g := game.NewWithSeed(3)
+	for x := 0; x < 10; x++ {
+		for y := 0; y < 6; y++ {
+			if (x+y)%4 != 0 {
+				g.Board[x][y] = game.Blue
+			}
+		}
+	}
+
+	// without looking ahead the best plan of the deal is chosen
+	player := NewExpectimax()
+	player.Depth = 0
+	plan, _ := Search{Weights: player.Weights, Width: player.Width}.Plan(g)
+	assert.Equal(plan[0], player.ChooseMove(g))
+
+	player = NewExpectimax()
+	move := player.ChooseMove(g)
+	assert.True(g.CanPlace(move.Block, move.X, move.Y))
+	assert.Equal(move, player.ChooseMove(g), "moves are reproducible by seed")
+
+	// budget of a single deal still gives legal move
+	player.Nodes = 1
+	move = player.ChooseMove(g)
+	assert.True(g.CanPlace(move.Block, move.X, move.Y))
+}
+
+func TestExpectimaxCandidates(t *testing.T) {
+	assert := assert.New(t)
+
+	// all first moves are valued, not only Width best plans
+	g := game.NewWithSeed(5)
+	for x := 0; x < 10; x++ {
+		for y := 0; y < 6; y++ {
+			if (x+y)%4 != 0 {
+				g.Board[x][y] = game.Blue
+			}
+		}
+	}
+	player := NewExpectimax()
+	candidates := player.Candidates(g)
+	assert.True(len(candidates) > 10*player.Width, "%d candidates", len(candidates))
+	assert.True(len(candidates) <= len(g.LegalMoves()))
+	seen := make(map[game.Move]bool)
+	for _, candidate := range candidates {
+		assert.False(seen[candidate.Move])
+		seen[candidate.Move] = true
+	}
+	for i := 1; i < len(candidates); i++ {
+		assert.True(candidates[i-1].Value >= candidates[i].Value)
+	}
+}
+
+func TestExpectimaxPlay(t *testing.T) {
+	assert := assert.New(t)
+
+	config := game.DefaultConfig()
+	config.MoveLimit = 15
+	player := NewExpectimax()
+	player.Samples = 4
+	final, err := Play(player, game.NewWithOptions(game.WithConfig(config)))
+	assert.Nil(err)
+	assert.Equal(15, final.MovesMade())
+}
+
+func TestBoardKey(t *testing.T) {
+	assert := assert.New(t)
+
+	board, _ := game.ParseRows([]string{"r.s", ".b."})
+	assert.Equal("#.s/.#./", boardKey(board))
+}
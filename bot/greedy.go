@@ -0,0 +1,88 @@
+package bot
+
+import (
+	"math"
+
+	"github.com/wrutkowski/go1010/game"
+)
+
+// Weights of the heuristics used by Greedy to compare boards after the move,
+// negative weights penalize the heuristic
+type Weights struct {
+	// Lines is the weight of each lane removed by the move
+	Lines float64 `json:"lines"`
+	// Holes is the weight of each empty cell surrounded by filled ones
+	Holes float64 `json:"holes"`
+	// Regions is the weight of each separate area of empty cells
+	Regions float64 `json:"regions"`
+	// Bumpiness is the weight of each border between filled and empty cell
+	Bumpiness float64 `json:"bumpiness"`
+	// FitsSquare is the weight of the 3x3 square still fitting on the board
+	FitsSquare float64 `json:"fitsSquare"`
+	// FitsLine is the weight of each orientation in which five cells long
+	// line still fits on the board
+	FitsLine float64 `json:"fitsLine"`
+}
+
+// DefaultWeights returns weights which keep the board open for big blocks
+// and prefer removing lanes
+func DefaultWeights() Weights {
+	return Weights{
+		Lines:      10,
+		Holes:      -8,
+		Regions:    -3,
+		Bumpiness:  -1,
+		FitsSquare: 20,
+		FitsLine:   10,
+	}
+}
+
+// evaluate returns weighted sum of the heuristics
+func (weights Weights) evaluate(h heuristics) float64 {
+	return weights.Lines*float64(h.lines) +
+		weights.Holes*float64(h.holes) +
+		weights.Regions*float64(h.regions) +
+		weights.Bumpiness*float64(h.bumpiness) +
+		weights.FitsSquare*float64(h.fitsSquare) +
+		weights.FitsLine*float64(h.fitsLine)
+}
+
+// Greedy is the Player choosing placement which gives the best board after
+// the move according to weighted heuristics, it does not look further ahead
+type Greedy struct {
+	Weights Weights
+}
+
+// NewGreedy returns Greedy player using DefaultWeights
+func NewGreedy() Greedy {
+	return Greedy{Weights: DefaultWeights()}
+}
+
+// ChooseMove returns the best legal placement, placements ending the game
+// before the next deal are chosen only when there is no other one. When no
+// block can be placed the first legal action, eg. reroll, is returned.
+func (player Greedy) ChooseMove(g game.Game) game.Move {
+	if candidates := player.Candidates(g); len(candidates) > 0 {
+		return candidates[0].Move
+	}
+	return fallbackMove(g)
+}
+
+// Candidates returns legal placements valued by weighted heuristics of the
+// board after the move, placements ending the game are valued at -Inf
+func (player Greedy) Candidates(g game.Game) []Candidate {
+	var candidates []Candidate
+	for _, move := range g.LegalMoves() {
+		next, result, err := g.Preview(move)
+		if err != nil {
+			continue
+		}
+		value := player.Weights.evaluate(measure(next.Board, result.LinesCleared()))
+		if result.GameOver {
+			value = math.Inf(-1)
+		}
+		candidates = append(candidates, Candidate{move, value})
+	}
+	SortCandidates(candidates)
+	return candidates
+}
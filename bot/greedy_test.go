@@ -0,0 +1,103 @@
+package bot
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wrutkowski/go1010/game"
+)
+
+// firstMove is the Player always choosing the first legal move
+var firstMove = PlayerFunc(func(g game.Game) game.Move {
+	return g.LegalMoves()[0]
+})
+
+func TestGreedyChooseMove(t *testing.T) {
+	assert := assert.New(t)
+
+	g := game.NewWithOptions(game.WithGenerator(game.NewScriptedGenerator(7, 0, 0)))
+	for y := 0; y < 5; y++ {
+		g.Board[9][y] = game.Red
+	}
+
+	// five horizontal completes the last row
+	assert.Equal(game.Move{Block: game.A, X: 9, Y: 5}, NewGreedy().ChooseMove(g))
+
+	// without other weights the first of equally good moves is chosen
+	assert.Equal(game.Move{Block: game.A, X: 0, Y: 0}, Greedy{}.ChooseMove(g))
+}
+
+func TestGreedyPlay(t *testing.T) {
+	assert := assert.New(t)
+
+	greedyScore, firstScore := 0, 0
+	for seed := int64(1); seed <= 3; seed++ {
+		g := game.NewWithSeed(seed)
+		final, err := Play(NewGreedy(), g)
+		assert.Nil(err)
+		assert.True(final.GameOver)
+		assert.False(g.GameOver, "played game is a copy")
+		greedyScore += final.Score
+
+		final, _ = Play(firstMove, g)
+		firstScore += final.Score
+	}
+	assert.True(greedyScore > 2*firstScore, "greedy %d, first move %d", greedyScore, firstScore)
+}
+
+func TestCandidates(t *testing.T) {
+	assert := assert.New(t)
+
+	config := game.DefaultConfig()
+	config.Blocks = 1
+	g := game.NewWithOptions(game.WithConfig(config), game.WithGenerator(game.NewScriptedGenerator(7)))
+	for y := 0; y < 5; y++ {
+		g.Board[9][y] = game.Red
+	}
+	mcts := NewMCTS()
+	mcts.RolloutDepth = 1
+
+	for _, player := range []Analyzer{NewGreedy(), NewSearch(), NewExpectimax(), mcts} {
+		candidates := player.Candidates(g)
+		assert.Equal(game.Move{Block: game.A, X: 9, Y: 5}, candidates[0].Move, "line is completed")
+		assert.Equal(candidates[0].Move, player.ChooseMove(g))
+		assert.True(len(candidates) <= len(g.LegalMoves()))
+	}
+
+	candidates := NewGreedy().Candidates(g)
+	assert.Equal(len(g.LegalMoves()), len(candidates))
+	for i := 1; i < len(candidates); i++ {
+		assert.True(candidates[i-1].Value >= candidates[i].Value)
+	}
+}
+
+func TestPlayInvalidMove(t *testing.T) {
+	assert := assert.New(t)
+
+	config := game.DefaultConfig()
+	config.InvalidMove = game.IgnoreInvalidMove
+	g := game.NewWithOptions(game.WithConfig(config))
+
+	final, err := Play(PlayerFunc(func(g game.Game) game.Move {
+		return game.Move{Block: game.A, X: 20, Y: 20}
+	}), g)
+	assert.True(game.IsInvalidMove(err))
+	assert.False(final.GameOver)
+}
+
+func TestPlayCyclingPlayer(t *testing.T) {
+	assert := assert.New(t)
+
+	config := game.DefaultConfig()
+	config.Hold = true
+	config.Rotation = true
+	config.Rerolls = 3
+	config.InvalidMove = game.IgnoreInvalidMove
+	g := game.NewWithOptions(game.WithConfig(config))
+
+	final, err := Play(PlayerFunc(func(g game.Game) game.Move {
+		return game.Move{Block: game.A, Action: game.HoldBlock}
+	}), g)
+	assert.True(game.IsInvalidMove(err))
+	assert.Equal(0, final.MovesMade())
+}
@@ -0,0 +1,138 @@
+package bot
+
+import (
+	"github.com/wrutkowski/go1010/game"
+)
+
+// heuristics describe the board after the move, stones count as filled cells
+type heuristics struct {
+	// lines is the number of lanes removed by the move
+	lines int
+	// holes is the number of empty cells with all neighbours filled or
+	// outside of the board, only a dot can be placed there
+	holes int
+	// regions is the number of separate areas of connected empty cells
+	regions int
+	// bumpiness is the number of neighbouring cells of which one is filled
+	// and the other empty, smooth boards have fewer of them
+	bumpiness int
+	// fitsSquare is 1 when 3x3 square can still be placed
+	fitsSquare int
+	// fitsLine is the number of orientations, 0 to 2, in which five cells
+	// long line can still be placed
+	fitsLine int
+}
+
+// measure returns heuristics of the board after removing a given number of
+// lanes
+func measure(board [][]game.BoardElement, lines int) heuristics {
+	h := heuristics{
+		lines:     lines,
+		holes:     Holes(board),
+		regions:   regions(board),
+		bumpiness: bumpiness(board),
+	}
+	if fitsAnywhere(board, 3, 3) {
+		h.fitsSquare = 1
+	}
+	if fitsAnywhere(board, 1, 5) {
+		h.fitsLine++
+	}
+	if fitsAnywhere(board, 5, 1) {
+		h.fitsLine++
+	}
+	return h
+}
+
+// IsFilled checks if x,y cell is filled, cells outside of the board are
+// treated as filled
+func IsFilled(board [][]game.BoardElement, x int, y int) bool {
+	if x < 0 || y < 0 || x >= len(board) || y >= len(board[x]) {
+		return true
+	}
+	return board[x][y] != game.None
+}
+
+// Holes returns the number of empty cells with all neighbours filled or
+// outside of the board, only a dot can be placed there
+func Holes(board [][]game.BoardElement) int {
+	count := 0
+	for x := range board {
+		for y := range board[x] {
+			if !IsFilled(board, x, y) && IsFilled(board, x-1, y) && IsFilled(board, x+1, y) && IsFilled(board, x, y-1) && IsFilled(board, x, y+1) {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+func regions(board [][]game.BoardElement) int {
+	visited := make([][]bool, len(board))
+	for x := range board {
+		visited[x] = make([]bool, len(board[x]))
+	}
+
+	count := 0
+	var stack [][2]int
+	for x := range board {
+		for y := range board[x] {
+			if visited[x][y] || IsFilled(board, x, y) {
+				continue
+			}
+			count++
+			visited[x][y] = true
+			stack = append(stack[:0], [2]int{x, y})
+			for len(stack) > 0 {
+				cell := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				for _, neighbour := range [][2]int{{cell[0] - 1, cell[1]}, {cell[0] + 1, cell[1]}, {cell[0], cell[1] - 1}, {cell[0], cell[1] + 1}} {
+					if IsFilled(board, neighbour[0], neighbour[1]) || visited[neighbour[0]][neighbour[1]] {
+						continue
+					}
+					visited[neighbour[0]][neighbour[1]] = true
+					stack = append(stack, neighbour)
+				}
+			}
+		}
+	}
+	return count
+}
+
+func bumpiness(board [][]game.BoardElement) int {
+	count := 0
+	for x := range board {
+		for y := range board[x] {
+			if x+1 < len(board) && IsFilled(board, x, y) != IsFilled(board, x+1, y) {
+				count++
+			}
+			if y+1 < len(board[x]) && IsFilled(board, x, y) != IsFilled(board, x, y+1) {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+// fitsAnywhere checks if a rectangle of a given size can be placed on the board
+func fitsAnywhere(board [][]game.BoardElement, rows int, columns int) bool {
+	for x := 0; x+rows <= len(board); x++ {
+		for y := 0; y+columns <= len(board[x]); y++ {
+			if fits(board, x, y, rows, columns) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func fits(board [][]game.BoardElement, x int, y int, rows int, columns int) bool {
+	for cellX := x; cellX < x+rows; cellX++ {
+		for cellY := y; cellY < y+columns; cellY++ {
+			if board[cellX][cellY] != game.None {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,32 @@
+package bot
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wrutkowski/go1010/game"
+)
+
+func TestMeasure(t *testing.T) {
+	assert := assert.New(t)
+
+	board, _ := game.ParseRows([]string{
+		".r...",
+		"r....",
+		".....",
+		"...s.",
+		"..s.s",
+	})
+	h := measure(board, 1)
+
+	assert.Equal(1, h.lines)
+	assert.Equal(2, h.holes, "0,0 and 4,3 are surrounded")
+	assert.Equal(3, h.regions)
+	assert.Equal(1, h.fitsSquare)
+	assert.Equal(1, h.fitsLine, "only horizontal line fits in row 2")
+	assert.Equal(15, h.bumpiness)
+
+	empty, _ := game.ParseRows([]string{"....", "....", "...."})
+	h = measure(empty, 0)
+	assert.Equal(heuristics{regions: 1, fitsSquare: 1}, h)
+}
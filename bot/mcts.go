@@ -0,0 +1,319 @@
+package bot
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/wrutkowski/go1010/game"
+)
+
+// MCTS is the Player using Monte Carlo Tree Search with UCT selection. Each
+// iteration walks down the tree of moves, adds a new move and plays the
+// game further with rollout moves, the points received update all moves on
+// the way. Blocks of the next deals are drawn uniformly from the catalogue
+// of the game in every iteration, so the tree holds statistics of moves
+// rather than states.
+type MCTS struct {
+	// Iterations limits the number of iterations and Budget the time of
+	// choosing a move. Search stops at whichever limit is reached first,
+	// 0 means no limit. 100 iterations are made when neither is set.
+	Iterations int
+	Budget     time.Duration
+	// Workers is the number of goroutines searching separate trees, their
+	// statistics are added up at the end
+	Workers int
+	// Exploration is the UCT constant balancing trying new moves against
+	// repeating good ones, points are scaled to 0..1 range
+	Exploration float64
+	// RolloutDepth limits the number of moves of each rollout, 0 means the
+	// rollout lasts until the game is over
+	RolloutDepth int
+	// Rollout returns the player choosing moves of the rollouts, random
+	// legal moves are made when it is nil. It is called once for every
+	// worker with the seed of the worker, so players keeping state, like
+	// Random or Neural, are not shared between goroutines.
+	Rollout func(seed int64) Player
+	// Seed of the generators drawing deals and random moves
+	Seed int64
+}
+
+// NewMCTS returns MCTS making 200 iterations with random rollouts of 20 moves
+// in a single goroutine
+func NewMCTS() MCTS {
+	return MCTS{
+		Iterations:   200,
+		Workers:      1,
+		Exploration:  math.Sqrt2,
+		RolloutDepth: 20,
+		Seed:         1,
+	}
+}
+
+// defaultIterations are made when neither Iterations nor Budget is set
+const defaultIterations = 100
+
+// MoveStats are statistics of the move collected by the search
+type MoveStats struct {
+	Move game.Move
+	// Visits is the number of iterations which started with the move
+	Visits int
+	// Value is the average number of points received after the move
+	Value float64
+}
+
+// ChooseMove returns the move visited most often. When no block can be
+// placed the first legal action, eg. reroll, is returned.
+func (player MCTS) ChooseMove(g game.Game) game.Move {
+	if stats := player.Analyze(g); len(stats) > 0 {
+		return stats[0].Move
+	}
+	return fallbackMove(g)
+}
+
+// Policy returns probability of each legal placement proportional to its
+// visits, it can be used as the target of imitation learning
+func (player MCTS) Policy(g game.Game) map[game.Move]float64 {
+	stats := player.Analyze(g)
+	total := 0
+	for _, moveStats := range stats {
+		total += moveStats.Visits
+	}
+	policy := make(map[game.Move]float64, len(stats))
+	for _, moveStats := range stats {
+		policy[moveStats.Move] = float64(moveStats.Visits) / float64(total)
+	}
+	return policy
+}
+
+// Candidates returns placements ordered by visits like Analyze, value of the
+// move is the average number of points received after it
+func (player MCTS) Candidates(g game.Game) []Candidate {
+	stats := player.Analyze(g)
+	candidates := make([]Candidate, len(stats))
+	for i, moveStats := range stats {
+		candidates[i] = Candidate{moveStats.Move, moveStats.Value}
+	}
+	return candidates
+}
+
+// Analyze runs the search and returns statistics of the placements possible
+// in the game, the most visited being the first
+func (player MCTS) Analyze(g game.Game) []MoveStats {
+	workers := player.Workers
+	if workers < 1 {
+		workers = 1
+	}
+	iterations := player.Iterations
+	if iterations == 0 && player.Budget == 0 {
+		iterations = defaultIterations
+	}
+	deadline := time.Time{}
+	if player.Budget > 0 {
+		deadline = time.Now().Add(player.Budget)
+	}
+
+	roots := make([]*mctsNode, workers)
+	var wait sync.WaitGroup
+	for i := range roots {
+		// iterations are split between workers, first ones make the rest
+		workerIterations := 0
+		if iterations > 0 {
+			workerIterations = iterations / workers
+			if i < iterations%workers {
+				workerIterations++
+			}
+		}
+		seed := player.Seed + int64(i)
+		tree := &mctsTree{
+			MCTS:   player,
+			root:   &mctsNode{},
+			random: rand.New(rand.NewSource(seed)),
+		}
+		if player.Rollout != nil {
+			tree.rolloutPlayer = player.Rollout(seed)
+		}
+		roots[i] = tree.root
+		wait.Add(1)
+		go func() {
+			defer wait.Done()
+			tree.search(g, workerIterations, deadline)
+		}()
+	}
+	wait.Wait()
+
+	return mergeRoots(roots)
+}
+
+// mctsNode holds statistics of the move reached by the path from the root
+type mctsNode struct {
+	move     game.Move
+	children []*mctsNode
+	visits   int
+	total    float64
+}
+
+// child returns child node of the move, nil when it was not expanded yet
+func (node *mctsNode) child(move game.Move) *mctsNode {
+	for _, child := range node.children {
+		if child.move == move {
+			return child
+		}
+	}
+	return nil
+}
+
+// mctsTree is the tree searched by a single worker
+type mctsTree struct {
+	MCTS
+	root   *mctsNode
+	random *rand.Rand
+	// rolloutPlayer is the player returned by Rollout for this worker
+	rolloutPlayer Player
+	// best is the highest number of points received so far, used to scale
+	// points to 0..1 range
+	best float64
+}
+
+// search makes iterations until the number of iterations or the deadline is
+// reached, zero values mean no limit
+func (tree *mctsTree) search(g game.Game, iterations int, deadline time.Time) {
+	for i := 0; iterations == 0 || i < iterations; i++ {
+		if !deadline.IsZero() && !time.Now().Before(deadline) {
+			break
+		}
+		tree.iterate(g)
+	}
+}
+
+// iterate selects the path of moves, expands it with a new move, makes the
+// rollout and updates statistics of the path
+func (tree *mctsTree) iterate(g game.Game) {
+	start := g.Score
+	path := []*mctsNode{tree.root}
+	node := tree.root
+	for !g.GameOver {
+		moves := g.LegalMoves()
+		if len(moves) == 0 {
+			break
+		}
+
+		var next *mctsNode
+		var unexpanded []game.Move
+		for _, move := range moves {
+			if node.child(move) == nil {
+				unexpanded = append(unexpanded, move)
+			}
+		}
+		expanded := len(unexpanded) > 0
+		if expanded {
+			next = &mctsNode{move: unexpanded[tree.random.Intn(len(unexpanded))]}
+			node.children = append(node.children, next)
+		} else {
+			next = tree.selectChild(node, moves)
+		}
+
+		g, _ = tree.step(g, next.move)
+		path = append(path, next)
+		node = next
+		if expanded {
+			break
+		}
+	}
+
+	reward := float64(tree.rollout(g) - start)
+	if reward > tree.best {
+		tree.best = reward
+	}
+	for _, node := range path {
+		node.visits++
+		node.total += reward
+	}
+}
+
+// selectChild returns child with the highest UCT value among legal moves
+func (tree *mctsTree) selectChild(node *mctsNode, moves []game.Move) *mctsNode {
+	scale := tree.best
+	if scale < 1 {
+		scale = 1
+	}
+	var best *mctsNode
+	bestValue := math.Inf(-1)
+	for _, move := range moves {
+		child := node.child(move)
+		value := child.total/float64(child.visits)/scale + tree.Exploration*math.Sqrt(math.Log(float64(node.visits))/float64(child.visits))
+		if value > bestValue {
+			best, bestValue = child, value
+		}
+	}
+	return best
+}
+
+// rollout plays the game with rollout moves and returns the final score
+func (tree *mctsTree) rollout(g game.Game) int {
+	for depth := 0; !g.GameOver && (tree.RolloutDepth == 0 || depth < tree.RolloutDepth); depth++ {
+		var move game.Move
+		if tree.rolloutPlayer != nil {
+			move = tree.rolloutPlayer.ChooseMove(g)
+		} else {
+			moves := g.LegalMoves()
+			if len(moves) == 0 {
+				break
+			}
+			move = moves[tree.random.Intn(len(moves))]
+		}
+		next, ok := tree.step(g, move)
+		if !ok {
+			break
+		}
+		g = next
+	}
+	return g.Score
+}
+
+// step makes the move on a copy of the game and deals random blocks when the
+// last block was used, second value is false when the move is not possible
+func (tree *mctsTree) step(g game.Game, move game.Move) (game.Game, bool) {
+	next, result, err := g.Preview(move)
+	if err != nil {
+		return g, false
+	}
+	if result.BlocksDealt && !next.GameOver {
+		next = dealRandom(next, tree.random)
+		if len(next.LegalMoves()) == 0 && len(next.LegalActions()) == 0 {
+			next.GameOver = true
+		}
+	}
+	return next, true
+}
+
+// mergeRoots adds up statistics of moves of all roots, the most visited move
+// is the first
+func mergeRoots(roots []*mctsNode) []MoveStats {
+	var stats []MoveStats
+	index := make(map[game.Move]int)
+	totals := make(map[game.Move]float64)
+	for _, root := range roots {
+		for _, child := range root.children {
+			i, ok := index[child.move]
+			if !ok {
+				i = len(stats)
+				index[child.move] = i
+				stats = append(stats, MoveStats{Move: child.move})
+			}
+			stats[i].Visits += child.visits
+			totals[child.move] += child.total
+		}
+	}
+	for i := range stats {
+		if stats[i].Visits > 0 {
+			stats[i].Value = totals[stats[i].Move] / float64(stats[i].Visits)
+		}
+	}
+	sort.SliceStable(stats, func(i, j int) bool {
+		return stats[i].Visits > stats[j].Visits
+	})
+	return stats
+}
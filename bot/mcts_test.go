@@ -0,0 +1,83 @@
+package bot
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wrutkowski/go1010/game"
+)
+
+func TestMCTSChooseMove(t *testing.T) {
+	assert := assert.New(t)
+
+	config := game.DefaultConfig()
+	config.Blocks = 1
+	g := game.NewWithOptions(game.WithConfig(config), game.WithGenerator(game.NewScriptedGenerator(7)))
+	for y := 0; y < 5; y++ {
+		g.Board[9][y] = game.Red
+	}
+
+	player := NewMCTS()
+	player.Iterations = 400
+	player.RolloutDepth = 1
+	stats := player.Analyze(g)
+	assert.Equal(game.Move{Block: game.A, X: 9, Y: 5}, stats[0].Move, "line is completed")
+	assert.True(stats[0].Value >= 10)
+	assert.Equal(stats[0].Move, player.ChooseMove(g))
+
+	visits := 0
+	for _, moveStats := range stats {
+		visits += moveStats.Visits
+	}
+	assert.Equal(400, visits)
+
+	total := 0.0
+	for _, probability := range player.Policy(g) {
+		total += probability
+	}
+	assert.InDelta(1, total, 1e-9)
+}
+
+func TestMCTSBudget(t *testing.T) {
+	assert := assert.New(t)
+
+	g := game.New()
+	player := NewMCTS()
+	player.Iterations = 0
+	player.Budget = 50 * time.Millisecond
+	player.Workers = 4
+	player.Rollout = func(int64) Player { return NewGreedy() }
+	player.RolloutDepth = 3
+
+	start := time.Now()
+	move := player.ChooseMove(g)
+	assert.True(time.Since(start) < time.Second)
+	assert.True(g.CanPlace(move.Block, move.X, move.Y))
+
+	player.Budget = 0
+	player.Iterations = 10
+	visits := 0
+	for _, moveStats := range player.Analyze(g) {
+		visits += moveStats.Visits
+	}
+	assert.Equal(10, visits, "iterations are split between workers")
+}
+
+func TestMCTSRolloutPerWorker(t *testing.T) {
+	assert := assert.New(t)
+
+	var seeds []int64
+	player := NewMCTS()
+	player.Iterations = 40
+	player.Workers = 4
+	player.Seed = 10
+	player.Rollout = func(seed int64) Player {
+		seeds = append(seeds, seed)
+		return NewRandom(seed)
+	}
+
+	move := player.ChooseMove(game.New())
+	assert.True(game.New().CanPlace(move.Block, move.X, move.Y))
+	assert.ElementsMatch([]int64{10, 11, 12, 13}, seeds, "each worker gets its own rollout player")
+}
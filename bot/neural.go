@@ -0,0 +1,130 @@
+package bot
+
+import (
+	"github.com/wrutkowski/go1010/game"
+	"github.com/wrutkowski/go1010/neural"
+)
+
+// Neural is the Player choosing moves with the neural network. Board and
+// blocks are the input of the network, outputs select the block, position
+// and action of the move, so the chosen move is often not possible. Network
+// keeps values of the last run, copies of the player sharing the network
+// cannot be used by several goroutines at once, see neural.Network.Clone.
+type Neural struct {
+	Network neural.Network
+}
+
+// ChooseMove runs the network with the game as the input
+func (player Neural) ChooseMove(g game.Game) game.Move {
+	return NeuralMove(player.Network.Run(NeuralInput(g)), g.Config())
+}
+
+// NeuralOutputs returns the number of network outputs: block, x and y of
+// the move and the action when any of the rule extensions is enabled
+func NeuralOutputs(config game.Config) int {
+	if len(neuralActions(config)) > 1 {
+		return 4
+	}
+	return 3
+}
+
+// neuralActions returns actions which can be chosen by the network
+func neuralActions(config game.Config) []game.Action {
+	actions := []game.Action{game.PlaceBlock}
+	if config.Hold {
+		actions = append(actions, game.HoldBlock)
+	}
+	if config.Rotation {
+		actions = append(actions, game.RotateBlock)
+	}
+	if config.Rerolls > 0 {
+		actions = append(actions, game.Reroll)
+	}
+	return actions
+}
+
+// NeuralMove returns the move selected by outputs of the network
+func NeuralMove(output []float32, config game.Config) game.Move {
+	// output range -1...1 is split into equal parts, one for each block, the
+	// hold slot being the last one
+	blocks := config.Blocks
+	if config.Hold {
+		blocks++
+	}
+	block := game.BlockType(outputRange(output[0], blocks))
+	if int(block) == config.Blocks {
+		block = game.Hold
+	}
+
+	outputX := ((output[1] + 1) / 2) * float32(config.Rows)
+	outputY := ((output[2] + 1) / 2) * float32(config.Columns)
+
+	action := game.PlaceBlock
+	if actions := neuralActions(config); len(output) > 3 {
+		action = actions[outputRange(output[3], len(actions))]
+	}
+
+	return game.Move{Block: block, X: int(outputX), Y: int(outputY), Action: action}
+}
+
+// outputRange returns which of count equal parts of -1...1 range contains
+// the output
+func outputRange(output float32, count int) int {
+	part := int(((output + 1) / 2) * float32(count))
+	if part >= count {
+		part = count - 1
+	}
+	if part < 0 {
+		part = 0
+	}
+	return part
+}
+
+// NeuralInput returns input of the network describing the game: filled cells
+// of the board, blocks and the held block, followed by the share of rerolls
+// left when rerolls are enabled. Each block takes the same part of the input,
+// its size is the biggest grid of the catalogue, so the number of inputs does
+// not depend on the blocks dealt.
+func NeuralInput(g game.Game) []float32 {
+	size := g.Shapes().GridSize()
+	input := make([]float32, 0, len(g.Board)*len(g.Board[0])+(len(g.Blocks)+1)*size*size+1)
+	input = appendContainerInput(input, g.Board)
+	for _, block := range g.Blocks {
+		input = appendBlockInput(input, block, size)
+	}
+	if g.Held != nil {
+		input = appendBlockInput(input, g.Held, size)
+	}
+	if rerolls := g.Config().Rerolls; rerolls > 0 {
+		input = append(input, float32(g.RerollsLeft())/float32(rerolls))
+	}
+	return input
+}
+
+// appendBlockInput adds filled cells of the block placed in top left corner
+// of a square of a given size
+func appendBlockInput(input []float32, block [][]game.BoardElement, size int) []float32 {
+	for x := 0; x < size; x++ {
+		for y := 0; y < size; y++ {
+			if x < len(block) && y < len(block[x]) && block[x][y] != game.None {
+				input = append(input, 1)
+			} else {
+				input = append(input, 0)
+			}
+		}
+	}
+	return input
+}
+
+func appendContainerInput(input []float32, container [][]game.BoardElement) []float32 {
+	for x := 0; x < len(container); x++ {
+		for y := 0; y < len(container[x]); y++ {
+			if container[x][y] != game.None {
+				input = append(input, 1)
+			} else {
+				input = append(input, 0)
+			}
+		}
+	}
+	return input
+}
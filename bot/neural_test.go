@@ -0,0 +1,44 @@
+package bot
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wrutkowski/go1010/game"
+	"github.com/wrutkowski/go1010/neural"
+)
+
+func TestNeuralMove(t *testing.T) {
+	assert := assert.New(t)
+
+	config := game.DefaultConfig()
+	assert.Equal(3, NeuralOutputs(config))
+	assert.Equal(game.Move{Block: game.C, X: 0, Y: 5}, NeuralMove([]float32{1, -1, 0}, config))
+
+	config.Hold = true
+	config.Rerolls = 3
+	assert.Equal(4, NeuralOutputs(config))
+	assert.Equal(game.Move{Block: game.Hold, X: 9, Y: 0, Action: game.Reroll}, NeuralMove([]float32{0.9, 0.99, -1, 1}, config))
+	assert.Equal(game.Move{Block: game.A, X: 5, Y: 5, Action: game.HoldBlock}, NeuralMove([]float32{-1, 0, 0, 0}, config))
+}
+
+func TestNeuralChooseMove(t *testing.T) {
+	assert := assert.New(t)
+
+	g := game.New()
+	input := NeuralInput(g)
+	assert.Equal(100+3*25, len(input))
+
+	// size of the input depends on the catalogue, not on the blocks dealt
+	shapes := game.NewCatalogue("big", game.NewShape("dot", game.Red, "#"), game.NewShape("six", game.Blue, "######"))
+	big := game.NewWithOptions(game.WithCatalogue(shapes), game.WithGenerator(game.NewScriptedGenerator(0, 0, 0, 1)))
+	assert.Equal(100+3*36, len(NeuralInput(big)))
+	assert.Nil(big.Move(game.A, 0, 0))
+	assert.Nil(big.Move(game.B, 1, 0))
+	assert.Nil(big.Move(game.C, 2, 0))
+	assert.Equal(100+3*36, len(NeuralInput(big)))
+
+	manager := neural.NewNetworkManager(len(input), NeuralOutputs(g.Config()), []int{4}, 3)
+	move := Neural{Network: manager.Networks[0]}.ChooseMove(g)
+	assert.True(move.X >= 0 && move.X < 10 && move.Y >= 0 && move.Y < 10)
+}
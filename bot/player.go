@@ -0,0 +1,72 @@
+package bot
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/wrutkowski/go1010/game"
+)
+
+// Player chooses moves in the game
+type Player interface {
+	ChooseMove(g game.Game) game.Move
+}
+
+// PlayerFunc is an adapter allowing to use function as Player
+type PlayerFunc func(g game.Game) game.Move
+
+// ChooseMove calls the function
+func (f PlayerFunc) ChooseMove(g game.Game) game.Move {
+	return f(g)
+}
+
+// Candidate is the move considered by the player together with its value
+type Candidate struct {
+	Move  game.Move
+	Value float64
+}
+
+// Analyzer is the Player which can tell how it values the moves, eg. to
+// show hints to human players
+type Analyzer interface {
+	Player
+	// Candidates returns legal placements with their values, the move which
+	// would be chosen being the first
+	Candidates(g game.Game) []Candidate
+}
+
+// SortCandidates sorts candidates by value, the first of equally valued
+// candidates stays first
+func SortCandidates(candidates []Candidate) {
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Value > candidates[j].Value
+	})
+}
+
+// MaxActions is the number of moves in a row without placing a block, eg.
+// holds, rotations or moves which are ignored by the game, after which Play
+// stops the game of the player
+const MaxActions = 100
+
+// Play plays a copy of the game with the player until the game is over and
+// returns the final state. Error is returned when the player chooses a move
+// which is not possible or makes MaxActions moves in a row without placing a
+// block, the game played up to that move is returned with it.
+func Play(player Player, g game.Game) (game.Game, error) {
+	g = g.Clone()
+	actions := 0
+	for !g.GameOver {
+		placed := g.MovesMade()
+		if err := g.Play(player.ChooseMove(g)); game.IsInvalidMove(err) {
+			return g, err
+		}
+		if g.MovesMade() > placed {
+			actions = 0
+			continue
+		}
+		if actions++; actions >= MaxActions {
+			return g, &game.ErrorGame{Reason: game.IncorrectAction, Message: fmt.Sprintf("No block was placed in %d moves", MaxActions)}
+		}
+	}
+	return g, nil
+}
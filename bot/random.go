@@ -0,0 +1,31 @@
+package bot
+
+import (
+	"math/rand"
+
+	"github.com/wrutkowski/go1010/game"
+)
+
+// Random is the Player choosing uniformly one of legal placements, it is the
+// baseline other players are compared with. Legal action, eg. reroll, is
+// chosen only when no block can be placed.
+type Random struct {
+	random *rand.Rand
+}
+
+// NewRandom returns Random player, the same seed gives the same moves in the
+// same game
+func NewRandom(seed int64) *Random {
+	return &Random{random: rand.New(rand.NewSource(seed))}
+}
+
+// ChooseMove returns random legal placement or action
+func (player *Random) ChooseMove(g game.Game) game.Move {
+	if moves := g.LegalMoves(); len(moves) > 0 {
+		return moves[player.random.Intn(len(moves))]
+	}
+	if actions := g.LegalActions(); len(actions) > 0 {
+		return actions[player.random.Intn(len(actions))]
+	}
+	return game.Move{}
+}
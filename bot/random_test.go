@@ -0,0 +1,21 @@
+package bot
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wrutkowski/go1010/game"
+)
+
+func TestRandomPlay(t *testing.T) {
+	assert := assert.New(t)
+
+	g := game.NewWithSeed(1)
+	final, err := Play(NewRandom(1), g)
+	assert.Nil(err, "random player makes only legal moves")
+	assert.True(final.GameOver)
+	assert.True(final.MovesMade() > 0)
+
+	again, _ := Play(NewRandom(1), g)
+	assert.Equal(final.Score, again.Score)
+}
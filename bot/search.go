@@ -0,0 +1,179 @@
+package bot
+
+import (
+	"math"
+	"sort"
+	"strconv"
+
+	"github.com/wrutkowski/go1010/game"
+)
+
+// Search is the Player planning placement of all blocks of the deal. It
+// tries every order of the blocks and every position of each of them, lanes
+// being removed between placements, and chooses the plan giving the best
+// board according to weighted heuristics once new blocks are dealt. Moves
+// are made with game.Preview, so the next blocks are not known. Plans
+// reaching the same board with the same blocks left are searched only once
+// and plans ending the game before the deal is finished are dropped.
+type Search struct {
+	Weights Weights
+	// Width limits placements of each block tried by the search to a given
+	// number of the best ones after a single move, 0 means all placements
+	// are tried. Exhaustive search of a nearly empty board takes seconds.
+	Width int
+}
+
+// NewSearch returns exhaustive Search using DefaultWeights
+func NewSearch() Search {
+	return Search{Weights: DefaultWeights()}
+}
+
+// ChooseMove returns the first move of the best plan. When every plan ends
+// the game the first legal move or action is returned.
+func (player Search) ChooseMove(g game.Game) game.Move {
+	if plan, _ := player.Plan(g); len(plan) > 0 {
+		return plan[0]
+	}
+	return fallbackMove(g)
+}
+
+// fallbackMove returns the first legal move or action, it is used when every
+// plan ends the game
+func fallbackMove(g game.Game) game.Move {
+	if moves := g.LegalMoves(); len(moves) > 0 {
+		return moves[0]
+	}
+	if actions := g.LegalActions(); len(actions) > 0 {
+		return actions[0]
+	}
+	return game.Move{}
+}
+
+// Plan returns placements of all blocks of the deal giving the best board
+// together with its value. Empty plan is returned when every plan ends the
+// game.
+func (player Search) Plan(g game.Game) ([]game.Move, float64) {
+	var best []game.Move
+	bestValue := math.Inf(-1)
+	player.plans(g, func(plan []game.Move, next game.Game, lines int) {
+		value := player.Weights.evaluate(measure(next.Board, lines))
+		if best == nil || value > bestValue {
+			best, bestValue = plan, value
+		}
+	})
+	return best, bestValue
+}
+
+// Candidates returns first moves of the plans valued by the best plan
+// starting with the move, moves of which every plan ends the game are not
+// included
+func (player Search) Candidates(g game.Game) []Candidate {
+	var candidates []Candidate
+	index := make(map[game.Move]int)
+	player.plans(g, func(plan []game.Move, next game.Game, lines int) {
+		value := player.Weights.evaluate(measure(next.Board, lines))
+		if i, ok := index[plan[0]]; ok {
+			if value > candidates[i].Value {
+				candidates[i].Value = value
+			}
+			return
+		}
+		index[plan[0]] = len(candidates)
+		candidates = append(candidates, Candidate{plan[0], value})
+	})
+	SortCandidates(candidates)
+	return candidates
+}
+
+// plans calls finished with every plan of the deal which does not end the
+// game, the game after the plan and the number of lanes removed by it
+func (player Search) plans(g game.Game, finished func(plan []game.Move, next game.Game, lines int)) {
+	s := search{Search: player, visited: make(map[string]bool), finished: finished}
+	s.search(g, nil, 0, remainingBlocks(g))
+}
+
+// search remembers positions already searched
+type search struct {
+	Search
+	visited  map[string]bool
+	finished func(plan []game.Move, next game.Game, lines int)
+}
+
+// candidate is the placement tried by the search
+type candidate struct {
+	move   game.Move
+	next   game.Game
+	result game.MoveResult
+	value  float64
+}
+
+func (s *search) search(g game.Game, plan []game.Move, lines int, depth int) {
+	for _, candidate := range s.candidates(g, lines) {
+		nextPlan := append(plan[:len(plan):len(plan)], candidate.move)
+		nextLines := lines + candidate.result.LinesCleared()
+
+		// game ended by the move limit is finished like after the deal
+		movesLeft, limited := candidate.next.MovesLeft()
+		if candidate.result.GameOver && !(limited && movesLeft == 0) {
+			continue
+		}
+		if candidate.result.BlocksDealt || candidate.result.GameOver || depth == 1 {
+			s.finished(nextPlan, candidate.next, nextLines)
+			continue
+		}
+
+		key := candidate.next.Notation() + " " + strconv.Itoa(nextLines)
+		if s.visited[key] {
+			continue
+		}
+		s.visited[key] = true
+		s.search(candidate.next, nextPlan, nextLines, depth-1)
+	}
+}
+
+// candidates returns placements possible in the game, only Width best ones
+// when the width is limited
+func (s *search) candidates(g game.Game, lines int) []candidate {
+	var candidates []candidate
+	for _, move := range g.LegalMoves() {
+		next, result, err := g.Preview(move)
+		if err != nil {
+			continue
+		}
+		candidates = append(candidates, candidate{move: move, next: next, result: result})
+	}
+	if s.Width <= 0 || len(candidates) <= s.Width {
+		return candidates
+	}
+
+	for i := range candidates {
+		candidates[i].value = s.Weights.evaluate(measure(candidates[i].next.Board, lines+candidates[i].result.LinesCleared()))
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].value > candidates[j].value
+	})
+	return candidates[:s.Width]
+}
+
+// remainingBlocks returns the number of blocks which can be placed before the
+// deal is finished, the held block included
+func remainingBlocks(g game.Game) int {
+	remaining := 0
+	for _, blockType := range g.BlockTypes() {
+		if block, _ := g.Block(blockType); !isBlockEmpty(block) {
+			remaining++
+		}
+	}
+	return remaining
+}
+
+func isBlockEmpty(block [][]game.BoardElement) bool {
+	for _, row := range block {
+		for _, element := range row {
+			if element != game.None {
+				return false
+			}
+		}
+	}
+	return true
+}
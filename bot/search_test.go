@@ -0,0 +1,60 @@
+package bot
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wrutkowski/go1010/game"
+)
+
+func TestSearchPlan(t *testing.T) {
+	assert := assert.New(t)
+
+	// line fits only in the gap of the first row, so the dot must be placed
+	// elsewhere
+	config := game.DefaultConfig()
+	config.Blocks = 2
+	config.KeepColumns = true
+	rows := []string{"rrrrr....."}
+	for x := 1; x < 8; x++ {
+		rows = append(rows, "rrrrrrrrr.")
+	}
+	rows = append(rows, "rrrrrrrr..", "rrrrrrrr..")
+	board, _ := game.ParseRows(rows)
+	g := game.NewWithOptions(game.WithConfig(config), game.WithBoard(board), game.WithGenerator(game.NewScriptedGenerator(0, 7)))
+
+	plan, _ := NewSearch().Plan(g)
+	assert.Equal(2, len(plan))
+	assert.Contains(plan, game.Move{Block: game.B, X: 0, Y: 5})
+
+	for _, move := range plan {
+		next, result, err := g.Preview(move)
+		assert.Nil(err)
+		assert.False(result.GameOver && !result.BlocksDealt)
+		g = next
+	}
+	assert.Equal(game.None, g.Board[0][0], "first row is removed")
+
+	// all plans end the game
+	g = game.NewWithOptions(game.WithConfig(config), game.WithBoard(board), game.WithGenerator(game.NewScriptedGenerator(10, 7)))
+	plan, _ = NewSearch().Plan(g)
+	assert.Empty(plan)
+	assert.Equal(g.LegalMoves()[0], NewSearch().ChooseMove(g))
+}
+
+func TestSearchPlay(t *testing.T) {
+	assert := assert.New(t)
+
+	player := NewSearch()
+	player.Width = 3
+	config := game.DefaultConfig()
+	config.MoveLimit = 60
+	g := game.NewWithOptions(game.WithConfig(config))
+
+	start := time.Now()
+	final, err := Play(player, g)
+	assert.Nil(err)
+	assert.Equal(60, final.MovesMade(), "search survives all moves")
+	assert.True(time.Since(start) < 10*time.Second)
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/wrutkowski/go1010/game"
 
@@ -40,47 +41,113 @@ func DrawGames(columns int, rows int, games []game.Game) {
 	}
 }
 
-// DrawGame draws board and three blocks
+// DrawGame draws board, all offered blocks and the held block when the hold
+// slot is enabled
 func DrawGame(g game.Game) {
+	DrawGameWithTitle(g, "go1010")
+}
+
+// DrawGameWithTitle draws board and all offered blocks in a window with
+// provided title
+func DrawGameWithTitle(g game.Game, title string) {
+	fmt.Printf("\033[0;0H")
+	fmt.Print(drawGame(g, title))
+}
+
+// DrawGameWithHint draws the game like DrawGame with cells which would be
+// filled by the placement highlighted on the board
+func DrawGameWithHint(g game.Game, move game.Move) {
 	fmt.Printf("\033[0;0H")
-	fmt.Print(drawGame(g, "go1010"))
+	fmt.Print(drawHighlightedGame(g, "go1010", moveCells(g, move)))
+}
+
+// moveCells returns cells of the board filled by the placement together with
+// the color of the block, cells outside of the board are skipped
+func moveCells(g game.Game, move game.Move) map[game.Cell]game.BoardElement {
+	cells := make(map[game.Cell]game.BoardElement)
+	if move.Action != game.PlaceBlock {
+		return cells
+	}
+	block, ok := g.Block(move.Block)
+	if !ok {
+		return cells
+	}
+	for x := range block {
+		for y := range block[x] {
+			cell := game.Cell{X: move.X + x, Y: move.Y + y}
+			if block[x][y] == game.None || cell.X < 0 || cell.Y < 0 || cell.X >= len(g.Board) || cell.Y >= len(g.Board[cell.X]) {
+				continue
+			}
+			cells[cell] = block[x][y]
+		}
+	}
+	return cells
 }
 
 func drawGame(g game.Game, title string) string {
-	boardDrawing := drawBoard(g.Board)
-	blockADrawing := drawBoard(g.BlockA)
-	blockBDrawing := drawBoard(g.BlockB)
-	blockCDrawing := drawBoard(g.BlockC)
+	return drawHighlightedGame(g, title, nil)
+}
 
-	boardDrawingLength := len(g.Board)*2 + 3
-	blockDrawingLength := len(g.BlockA)*2 + 3
+// drawHighlightedGame draws the game with given cells of the board drawn in
+// given colors and marked
+func drawHighlightedGame(g game.Game, title string, highlighted map[game.Cell]game.BoardElement) string {
+	boardDrawing := drawHighlightedBoard(g.Board, highlighted)
+	boardDrawingLength := len(g.Board[0])*2 + 3
 
-	// add bottom margin to blocks
-	for i := 0; i < 5; i++ {
-		blockADrawing += "\n"
-		for j := 0; j < blockDrawingLength; j++ {
-			blockADrawing += " "
-		}
-		blockBDrawing += "\n"
-		for j := 0; j < blockDrawingLength; j++ {
-			blockBDrawing += " "
-		}
-		blockCDrawing += "\n"
-		for j := 0; j < blockDrawingLength; j++ {
-			blockCDrawing += " "
-		}
+	drawings := []string{boardDrawing}
+	contentWidth := boardDrawingLength + 1
+	for _, block := range g.Blocks {
+		drawings = append(drawings, drawBlock(block, len(g.Board), ""))
+		contentWidth += len(block[0])*2 + 4
+	}
+	if g.Held != nil {
+		drawings = append(drawings, drawBlock(g.Held, len(g.Board), "hold"))
+		contentWidth += len(g.Held[0])*2 + 4
 	}
 
-	gameOver := ""
+	status := title + " | score: " + strconv.Itoa(g.Score)
+	if g.Config().Rerolls > 0 {
+		status += " | rerolls: " + strconv.Itoa(g.RerollsLeft())
+	}
+	if movesLeft, ok := g.MovesLeft(); ok {
+		status += " | moves left: " + strconv.Itoa(movesLeft)
+	}
+	if timeLeft, ok := g.TimeLeft(); ok {
+		status += " | time left: " + timeLeft.Round(time.Second).String()
+	}
 	if g.GameOver {
-		gameOver = " - GAME OVER"
+		status += " - GAME OVER"
 	}
-	gameArea := mergeBoardsHorizontally(" ", boardDrawing, blockADrawing, blockBDrawing, blockCDrawing)
-	window := windowAround(title+" | score: "+strconv.Itoa(g.Score)+gameOver, gameArea, boardDrawingLength+3*blockDrawingLength+4)
+	gameArea := mergeBoardsHorizontally(" ", drawings...)
+	window := windowAround(status, gameArea, contentWidth)
 
 	return window
 }
 
+// drawBlock draws the block with bottom margin so it is as high as the board.
+// Label, when provided, replaces the column numbers above the block.
+func drawBlock(block [][]game.BoardElement, height int, label string) string {
+	blockDrawing := drawBoard(block)
+	blockDrawingLength := len(block[0])*2 + 3
+
+	if label != "" {
+		lines := strings.SplitN(blockDrawing, "\n", 2)
+		header := "  " + label
+		for len(header) < blockDrawingLength {
+			header += " "
+		}
+		blockDrawing = header + "\n" + lines[1]
+	}
+
+	for i := len(block); i < height; i++ {
+		blockDrawing += "\n"
+		for j := 0; j < blockDrawingLength; j++ {
+			blockDrawing += " "
+		}
+	}
+	return blockDrawing
+}
+
 func boardElementToString(element game.BoardElement) string {
 	switch element {
 	case game.None:
@@ -99,6 +166,8 @@ func boardElementToString(element game.BoardElement) string {
 		return "\033[46m \033[0m"
 	case game.White:
 		return "\033[47m \033[0m"
+	case game.Stone:
+		return "\033[100m\u2591\033[0m"
 	}
 	return ""
 }
@@ -134,10 +203,20 @@ func windowAround(title string, content string, contentWidth int) string {
 	return s
 }
 
+// highlightedElementToString draws the element marked with a shaded
+// character, it shows cells of the suggested placement
+func highlightedElementToString(element game.BoardElement) string {
+	return strings.Replace(boardElementToString(element), " ", "\u2592", 1)
+}
+
 func drawBoard(board [][]game.BoardElement) string {
+	return drawHighlightedBoard(board, nil)
+}
+
+func drawHighlightedBoard(board [][]game.BoardElement, highlighted map[game.Cell]game.BoardElement) string {
 	s := "  "
 	for x := 0; x < len(board[0]); x++ {
-		s += strconv.Itoa(x) + " "
+		s += strconv.Itoa(x%10) + " "
 	}
 	s += " \n \u250F"
 	for x := 0; x < 2*len(board[0]); x++ {
@@ -147,9 +226,13 @@ func drawBoard(board [][]game.BoardElement) string {
 	for x := 0; x < len(board); x++ {
 		for y := 0; y < len(board[x]); y++ {
 			if y == 0 {
-				s += strconv.Itoa(x) + "\u2503"
+				s += strconv.Itoa(x%10) + "\u2503"
+			}
+			if element, ok := highlighted[game.Cell{X: x, Y: y}]; ok {
+				s += highlightedElementToString(element) + highlightedElementToString(element)
+			} else {
+				s += boardElementToString(board[x][y]) + boardElementToString(board[x][y])
 			}
-			s += boardElementToString(board[x][y]) + boardElementToString(board[x][y])
 			if y == len(board[x])-1 {
 				s += "\u2503"
 			}
@@ -1,7 +1,9 @@
 package drawer
 
 import (
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/wrutkowski/go1010/game"
@@ -56,3 +58,64 @@ func TestDrawBoard(t *testing.T) {
 	}
 
 }
+
+func TestDrawGame(t *testing.T) {
+	assert := assert.New(t)
+
+	config := game.DefaultConfig()
+	config.Rows = 8
+	config.Columns = 12
+	config.Blocks = 2
+	g := game.NewWithOptions(game.WithConfig(config))
+
+	lines := strings.Split(drawGame(g, "title"), "\n")
+
+	// window border, header line and borders of the board
+	assert.Equal(8+3+2+1, len(lines))
+	assert.True(strings.HasPrefix(lines[0], "\u250C\u2500 title | score: 0 "))
+	assert.Equal("\u2502  0 1 2 3 4 5 6 7 8 9 0 1     0 1 2 3 4     0 1 2 3 4   \u2502", lines[1])
+}
+
+func TestDrawGameWithHold(t *testing.T) {
+	assert := assert.New(t)
+
+	config := game.DefaultConfig()
+	config.Blocks = 1
+	config.Hold = true
+	config.Rerolls = 2
+	g := game.NewWithOptions(game.WithConfig(config))
+
+	lines := strings.Split(drawGame(g, "title"), "\n")
+
+	assert.True(strings.HasPrefix(lines[0], "┌─ title | score: 0 | rerolls: 2 "))
+	assert.Equal("│  0 1 2 3 4 5 6 7 8 9     0 1 2 3 4     hold        │", lines[1])
+	assert.Equal(len([]rune(lines[0])), len([]rune(lines[len(lines)-2])))
+}
+
+func TestDrawGameWithLimits(t *testing.T) {
+	assert := assert.New(t)
+
+	config := game.DefaultConfig()
+	config.MoveLimit = 20
+	config.TimeLimit = 90 * time.Second
+	g := game.NewWithOptions(game.WithConfig(config), game.WithClock(game.NewManualClock(time.Now())))
+
+	lines := strings.Split(drawGame(g, "title"), "\n")
+
+	assert.True(strings.HasPrefix(lines[0], "┌─ title | score: 0 | moves left: 20 | time left: 1m30s "))
+}
+
+func TestDrawHighlightedGame(t *testing.T) {
+	assert := assert.New(t)
+
+	g := game.NewWithOptions(game.WithGenerator(game.NewScriptedGenerator(15, 0, 0)))
+	cells := moveCells(g, game.Move{Block: game.A, X: 8, Y: 9})
+	assert.Equal(map[game.Cell]game.BoardElement{{X: 8, Y: 9}: game.White, {X: 9, Y: 9}: game.White}, cells, "cells outside of the board are skipped")
+	assert.Empty(moveCells(g, game.Move{Block: game.A, Action: game.Reroll}))
+
+	lines := strings.Split(drawHighlightedGame(g, "title", cells), "\n")
+	highlighted := highlightedElementToString(game.White)
+	assert.Equal(2, strings.Count(lines[11], highlighted), "cell 8,9")
+	assert.Equal(0, strings.Count(lines[10], highlighted))
+	assert.NotEqual(drawGame(g, "title"), drawHighlightedGame(g, "title", cells))
+}
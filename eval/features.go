@@ -0,0 +1,236 @@
+package eval
+
+import (
+	"github.com/wrutkowski/go1010/bot"
+	"github.com/wrutkowski/go1010/game"
+)
+
+// Indexes of the board features preceding fit counts of the shapes
+const (
+	// Filled is the number of filled cells, stones included
+	Filled = iota
+	// Holes is the number of empty cells with all neighbours filled or
+	// outside of the board, only a dot can be placed there
+	Holes
+	// IsolatedEmpties is the number of empty cells in areas of fewer than
+	// three connected empty cells, only the smallest blocks fit there
+	IsolatedEmpties
+	// RowTransitions is the number of horizontally neighbouring cells of
+	// which one is filled and the other empty, walls count as filled
+	RowTransitions
+	// ColumnTransitions is the same as RowTransitions for vertically
+	// neighbouring cells
+	ColumnTransitions
+	// LargestRectangle is the area of the largest rectangle of empty cells
+	LargestRectangle
+	// FitCounts is the index of the first fit count, fit count of each shape
+	// of the catalogue is the number of positions where the shape can be
+	// placed
+	FitCounts
+)
+
+// isolatedArea is the size of the area of connected empty cells from which
+// the cells are no longer counted as isolated
+const isolatedArea = 3
+
+// featureNames are names of the features preceding fit counts
+var featureNames = []string{
+	"filled",
+	"holes",
+	"isolated empties",
+	"row transitions",
+	"column transitions",
+	"largest rectangle",
+}
+
+// Extractor computes features of the board. Fit counts are computed for the
+// shapes of the catalogue, the default catalogue is used when it is nil.
+type Extractor struct {
+	Catalogue *game.Catalogue
+}
+
+// NewExtractor returns Extractor counting fits of 19 shapes of the original
+// game
+func NewExtractor() Extractor {
+	return Extractor{Catalogue: game.DefaultCatalogue()}
+}
+
+// Extract returns features of the board computed by NewExtractor
+func Extract(board [][]game.BoardElement) []float64 {
+	return NewExtractor().Extract(board)
+}
+
+func (extractor Extractor) catalogue() *game.Catalogue {
+	if extractor.Catalogue == nil {
+		return game.DefaultCatalogue()
+	}
+	return extractor.Catalogue
+}
+
+// Len returns the number of features
+func (extractor Extractor) Len() int {
+	return FitCounts + extractor.catalogue().Len()
+}
+
+// Names returns names of the features in the order of Extract, fit counts
+// are named after the shapes
+func (extractor Extractor) Names() []string {
+	names := append([]string(nil), featureNames...)
+	for _, shape := range extractor.catalogue().Shapes {
+		names = append(names, "fits "+shape.Name)
+	}
+	return names
+}
+
+// Extract returns vector of features of the board
+func (extractor Extractor) Extract(board [][]game.BoardElement) []float64 {
+	features := make([]float64, extractor.Len())
+	features[Filled] = float64(filledCells(board))
+	features[Holes] = float64(bot.Holes(board))
+	features[IsolatedEmpties] = float64(isolatedEmpties(board))
+	features[RowTransitions] = float64(rowTransitions(board))
+	features[ColumnTransitions] = float64(columnTransitions(board))
+	features[LargestRectangle] = float64(largestRectangle(board))
+	for i, shape := range extractor.catalogue().Shapes {
+		features[FitCounts+i] = float64(fitCount(board, shape))
+	}
+	return features
+}
+
+func filledCells(board [][]game.BoardElement) int {
+	count := 0
+	for x := range board {
+		for y := range board[x] {
+			if bot.IsFilled(board, x, y) {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+func isolatedEmpties(board [][]game.BoardElement) int {
+	visited := make([][]bool, len(board))
+	for x := range board {
+		visited[x] = make([]bool, len(board[x]))
+	}
+
+	count := 0
+	var area []game.Cell
+	for x := range board {
+		for y := range board[x] {
+			if visited[x][y] || bot.IsFilled(board, x, y) {
+				continue
+			}
+			visited[x][y] = true
+			area = append(area[:0], game.Cell{X: x, Y: y})
+			// area grows while its cells are visited, cells are never removed
+			for i := 0; i < len(area); i++ {
+				cell := area[i]
+				for _, neighbour := range []game.Cell{{X: cell.X - 1, Y: cell.Y}, {X: cell.X + 1, Y: cell.Y}, {X: cell.X, Y: cell.Y - 1}, {X: cell.X, Y: cell.Y + 1}} {
+					if bot.IsFilled(board, neighbour.X, neighbour.Y) || visited[neighbour.X][neighbour.Y] {
+						continue
+					}
+					visited[neighbour.X][neighbour.Y] = true
+					area = append(area, neighbour)
+				}
+			}
+			if len(area) < isolatedArea {
+				count += len(area)
+			}
+		}
+	}
+	return count
+}
+
+func rowTransitions(board [][]game.BoardElement) int {
+	count := 0
+	for x := range board {
+		for y := 0; y <= len(board[x]); y++ {
+			if bot.IsFilled(board, x, y-1) != bot.IsFilled(board, x, y) {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+func columnTransitions(board [][]game.BoardElement) int {
+	if len(board) == 0 {
+		return 0
+	}
+	count := 0
+	for y := range board[0] {
+		for x := 0; x <= len(board); x++ {
+			if bot.IsFilled(board, x-1, y) != bot.IsFilled(board, x, y) {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+// largestRectangle returns the area of the largest rectangle of empty cells.
+// Each row is the base of a histogram of empty cells above it and the largest
+// rectangle of each histogram is found with a stack of increasing heights.
+func largestRectangle(board [][]game.BoardElement) int {
+	if len(board) == 0 {
+		return 0
+	}
+	columns := len(board[0])
+	heights := make([]int, columns+1)
+	stack := make([]int, 0, columns+1)
+	largest := 0
+	for x := range board {
+		for y := 0; y < columns; y++ {
+			if bot.IsFilled(board, x, y) {
+				heights[y] = 0
+			} else {
+				heights[y]++
+			}
+		}
+		// heights[columns] stays 0 and empties the stack
+		stack = stack[:0]
+		for y := 0; y <= columns; y++ {
+			for len(stack) > 0 && heights[stack[len(stack)-1]] >= heights[y] {
+				height := heights[stack[len(stack)-1]]
+				stack = stack[:len(stack)-1]
+				left := -1
+				if len(stack) > 0 {
+					left = stack[len(stack)-1]
+				}
+				if area := height * (y - left - 1); area > largest {
+					largest = area
+				}
+			}
+			stack = append(stack, y)
+		}
+	}
+	return largest
+}
+
+// fitCount returns the number of positions where the shape can be placed
+func fitCount(board [][]game.BoardElement, shape game.Shape) int {
+	if len(board) == 0 || len(shape.Cells) == 0 {
+		return 0
+	}
+	rows, columns := shape.Size()
+	count := 0
+	for x := 0; x+rows <= len(board); x++ {
+		for y := 0; y+columns <= len(board[x]); y++ {
+			if fits(board, shape, x, y) {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+func fits(board [][]game.BoardElement, shape game.Shape, x int, y int) bool {
+	for _, cell := range shape.Cells {
+		if board[x+cell.X][y+cell.Y] != game.None {
+			return false
+		}
+	}
+	return true
+}
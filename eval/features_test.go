@@ -0,0 +1,60 @@
+package eval
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wrutkowski/go1010/game"
+)
+
+func TestExtract(t *testing.T) {
+	assert := assert.New(t)
+
+	board, _ := game.ParseRows([]string{
+		"r.r..",
+		"rrr..",
+		".....",
+		"ss.ss",
+	})
+	features := Extract(board)
+
+	assert.Equal(25, len(features))
+	assert.Equal(9.0, features[Filled])
+	assert.Equal(1.0, features[Holes], "0,1 is surrounded")
+	assert.Equal(1.0, features[IsolatedEmpties])
+	assert.Equal(10.0, features[RowTransitions])
+	assert.Equal(12.0, features[ColumnTransitions])
+	assert.Equal(6.0, features[LargestRectangle], "columns 3 and 4 of rows 0 to 2")
+	assert.Equal(11.0, features[FitCounts], "dot")
+	assert.Equal(6.0, features[FitCounts+1], "two horizontal")
+	assert.Equal(1.0, features[FitCounts+7], "five horizontal")
+	assert.Equal(2.0, features[FitCounts+9], "square two")
+	assert.Equal(0.0, features[FitCounts+10], "square three")
+
+	empty, _ := game.ParseRows([]string{"...", "..."})
+	features = Extract(empty)
+	assert.Equal(0.0, features[Holes])
+	assert.Equal(0.0, features[IsolatedEmpties])
+	assert.Equal(4.0, features[RowTransitions])
+	assert.Equal(6.0, features[ColumnTransitions])
+	assert.Equal(6.0, features[LargestRectangle])
+	assert.Equal(2.0, features[FitCounts+3], "three horizontal")
+}
+
+func TestExtractorNames(t *testing.T) {
+	assert := assert.New(t)
+
+	names := NewExtractor().Names()
+	assert.Equal(25, len(names))
+	assert.Equal("filled", names[Filled])
+	assert.Equal("largest rectangle", names[LargestRectangle])
+	assert.Equal("fits dot", names[FitCounts])
+
+	catalogue := game.NewCatalogue("lines", game.NewShape("line", game.Red, "###"))
+	extractor := Extractor{Catalogue: catalogue}
+	assert.Equal(7, extractor.Len())
+	assert.Equal("fits line", extractor.Names()[FitCounts])
+
+	board, _ := game.ParseRows([]string{"....", "r..."})
+	assert.Equal(3.0, extractor.Extract(board)[FitCounts])
+}
@@ -0,0 +1,88 @@
+package eval
+
+import (
+	"math"
+
+	"github.com/wrutkowski/go1010/bot"
+	"github.com/wrutkowski/go1010/game"
+)
+
+// Linear evaluates the board as the weighted sum of its features, it is also
+// the Player choosing placement which gives the best board after the move
+type Linear struct {
+	Extractor Extractor
+	// Weights of the features in the order of Extractor.Names, missing
+	// weights are treated as 0
+	Weights []float64
+}
+
+// NewLinear returns Linear using NewExtractor with given weights
+func NewLinear(weights []float64) Linear {
+	return Linear{Extractor: NewExtractor(), Weights: weights}
+}
+
+// DefaultLinear returns hand-tuned Linear which keeps the board open and
+// smooth, fit counts of bigger shapes are valued more
+func DefaultLinear() Linear {
+	extractor := NewExtractor()
+	weights := make([]float64, extractor.Len())
+	weights[Filled] = -1
+	weights[Holes] = -6
+	weights[IsolatedEmpties] = -3
+	weights[RowTransitions] = -1
+	weights[ColumnTransitions] = -1
+	weights[LargestRectangle] = 0.5
+	for i, shape := range extractor.catalogue().Shapes {
+		weights[FitCounts+i] = 0.02 * float64(len(shape.Cells))
+	}
+	return Linear{Extractor: extractor, Weights: weights}
+}
+
+// Evaluate returns value of the board, better boards have higher values
+func (linear Linear) Evaluate(board [][]game.BoardElement) float64 {
+	return linear.Value(linear.Extractor.Extract(board))
+}
+
+// Value returns weighted sum of the features
+func (linear Linear) Value(features []float64) float64 {
+	value := 0.0
+	for i, weight := range linear.Weights {
+		if i < len(features) {
+			value += weight * features[i]
+		}
+	}
+	return value
+}
+
+// ChooseMove returns the legal placement giving the best board, placements
+// ending the game before the next deal are chosen only when there is no other
+// one. When no block can be placed the first legal action, eg. reroll, is
+// returned.
+func (linear Linear) ChooseMove(g game.Game) game.Move {
+	if candidates := linear.Candidates(g); len(candidates) > 0 {
+		return candidates[0].Move
+	}
+	if actions := g.LegalActions(); len(actions) > 0 {
+		return actions[0]
+	}
+	return game.Move{}
+}
+
+// Candidates returns legal placements valued by the board after the move,
+// placements ending the game are valued at -Inf
+func (linear Linear) Candidates(g game.Game) []bot.Candidate {
+	var candidates []bot.Candidate
+	for _, move := range g.LegalMoves() {
+		next, result, err := g.Preview(move)
+		if err != nil {
+			continue
+		}
+		value := linear.Evaluate(next.Board)
+		if result.GameOver {
+			value = math.Inf(-1)
+		}
+		candidates = append(candidates, bot.Candidate{Move: move, Value: value})
+	}
+	bot.SortCandidates(candidates)
+	return candidates
+}
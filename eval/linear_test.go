@@ -0,0 +1,52 @@
+package eval
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wrutkowski/go1010/bot"
+	"github.com/wrutkowski/go1010/game"
+)
+
+func TestLinearValue(t *testing.T) {
+	assert := assert.New(t)
+
+	linear := NewLinear([]float64{1, -2})
+	assert.Equal(-3.0, linear.Value([]float64{1, 2, 100}), "missing weights are 0")
+	assert.Equal(1.0, linear.Value([]float64{1}), "missing features are ignored")
+
+	board, _ := game.ParseRows([]string{"r..", "..."})
+	assert.Equal(1.0, linear.Evaluate(board))
+	assert.Equal(25, len(DefaultLinear().Weights))
+}
+
+func TestLinearChooseMove(t *testing.T) {
+	assert := assert.New(t)
+
+	g := game.NewWithOptions(game.WithGenerator(game.NewScriptedGenerator(7, 0, 0)))
+	for y := 0; y < 5; y++ {
+		g.Board[9][y] = game.Red
+	}
+
+	// five horizontal completes the last row
+	assert.Equal(game.Move{Block: game.A, X: 9, Y: 5}, DefaultLinear().ChooseMove(g))
+}
+
+func TestLinearPlay(t *testing.T) {
+	assert := assert.New(t)
+
+	firstMove := bot.PlayerFunc(func(g game.Game) game.Move {
+		return g.LegalMoves()[0]
+	})
+	linearScore, firstScore := 0, 0
+	for seed := int64(1); seed <= 3; seed++ {
+		g := game.NewWithSeed(seed)
+		final, err := bot.Play(DefaultLinear(), g)
+		assert.Nil(err)
+		linearScore += final.Score
+
+		final, _ = bot.Play(firstMove, g)
+		firstScore += final.Score
+	}
+	assert.True(linearScore > 2*firstScore, "linear %d, first move %d", linearScore, firstScore)
+}
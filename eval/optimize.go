@@ -0,0 +1,187 @@
+package eval
+
+import (
+	"io"
+	"math"
+	"math/rand"
+	"sort"
+
+	"github.com/wrutkowski/go1010/bot"
+	"github.com/wrutkowski/go1010/game"
+	"github.com/wrutkowski/go1010/neural"
+)
+
+// Fitness tells how well the evaluator plays, higher is better
+type Fitness func(linear Linear) float64
+
+// GamesFitness returns Fitness being the average score of games played by the
+// evaluator, one game for each of the seeds. Options configure the games, eg.
+// move limit keeps them short.
+func GamesFitness(seeds []int64, options ...game.Option) Fitness {
+	return func(linear Linear) float64 {
+		if len(seeds) == 0 {
+			return 0
+		}
+		total := 0
+		for _, seed := range seeds {
+			g := game.NewWithOptions(append(options[:len(options):len(options)], game.WithSeed(seed))...)
+			final, _ := bot.Play(linear, g)
+			total += final.Score
+		}
+		return float64(total) / float64(len(seeds))
+	}
+}
+
+// CrossEntropy optimizes weights with the cross-entropy method. Candidates
+// of each generation are drawn from normal distribution of each weight, the
+// distribution is then fitted to the elite candidates with the best fitness.
+type CrossEntropy struct {
+	// Population is the number of candidates of each generation
+	Population int
+	// Elite is the number of the best candidates the distribution is
+	// fitted to
+	Elite int
+	// Generations is the number of generations evaluated
+	Generations int
+	// Deviation is the initial standard deviation of the weights
+	Deviation float64
+	// Noise is added to the deviation in each generation, so that the
+	// distribution does not collapse too early
+	Noise float64
+	// Seed of the generator drawing candidates
+	Seed int64
+}
+
+// NewCrossEntropy returns CrossEntropy evaluating 20 generations of 40
+// candidates, 8 of them being the elite
+func NewCrossEntropy() CrossEntropy {
+	return CrossEntropy{
+		Population:  40,
+		Elite:       8,
+		Generations: 20,
+		Deviation:   1,
+		Noise:       0.1,
+		Seed:        1,
+	}
+}
+
+// Optimize searches weights starting from the weights of a given evaluator
+// and returns the best evaluator found together with its fitness
+func (method CrossEntropy) Optimize(start Linear, fitness Fitness) (Linear, float64) {
+	population := method.Population
+	if population < 1 {
+		population = 1
+	}
+	elite := method.Elite
+	if elite < 1 {
+		elite = 1
+	}
+	if elite > population {
+		elite = population
+	}
+
+	size := start.Extractor.Len()
+	mean := make([]float64, size)
+	copy(mean, start.Weights)
+	deviation := make([]float64, size)
+	for i := range deviation {
+		deviation[i] = method.Deviation
+	}
+
+	random := rand.New(rand.NewSource(method.Seed))
+	best := Linear{Extractor: start.Extractor, Weights: mean}
+	bestFitness := math.Inf(-1)
+	type candidate struct {
+		weights []float64
+		fitness float64
+	}
+	candidates := make([]candidate, population)
+	for generation := 0; generation < method.Generations; generation++ {
+		for i := range candidates {
+			weights := make([]float64, size)
+			for j := range weights {
+				weights[j] = mean[j] + deviation[j]*random.NormFloat64()
+			}
+			linear := Linear{Extractor: start.Extractor, Weights: weights}
+			candidates[i] = candidate{weights, fitness(linear)}
+			if candidates[i].fitness > bestFitness {
+				best, bestFitness = linear, candidates[i].fitness
+			}
+		}
+
+		sort.SliceStable(candidates, func(i, j int) bool {
+			return candidates[i].fitness > candidates[j].fitness
+		})
+		for j := range mean {
+			sum := 0.0
+			for _, candidate := range candidates[:elite] {
+				sum += candidate.weights[j]
+			}
+			mean[j] = sum / float64(elite)
+
+			variance := 0.0
+			for _, candidate := range candidates[:elite] {
+				variance += (candidate.weights[j] - mean[j]) * (candidate.weights[j] - mean[j])
+			}
+			deviation[j] = math.Sqrt(variance/float64(elite)) + method.Noise
+		}
+	}
+	return best, bestFitness
+}
+
+// Genetic optimizes weights with the genetic algorithm of
+// neural.NetworkManager. Each network has no hidden layers and a single
+// output, its weights are the weights of the evaluator. Networks are
+// randomized by the manager, so the results differ between runs.
+type Genetic struct {
+	// Population is the number of networks, at least 3
+	Population int
+	// Generations is the number of generations evaluated
+	Generations int
+	// Output receives fitness of the top networks of each generation,
+	// nothing is written when it is nil
+	Output io.Writer
+}
+
+// NewGenetic returns Genetic evaluating 20 generations of 50 networks
+func NewGenetic() Genetic {
+	return Genetic{Population: 50, Generations: 20}
+}
+
+// Optimize searches weights of the features of a given extractor and returns
+// the best evaluator found together with its fitness
+func (method Genetic) Optimize(extractor Extractor, fitness Fitness) (Linear, float64) {
+	population := method.Population
+	if population < 3 {
+		population = 3
+	}
+	manager := neural.NewNetworkManager(extractor.Len(), 1, nil, population)
+	manager.Output = method.Output
+
+	var best Linear
+	bestFitness := math.Inf(-1)
+	for generation := 0; generation < method.Generations; generation++ {
+		if generation > 0 {
+			manager.NextGeneration()
+		}
+		for i := range manager.Networks {
+			linear := Linear{Extractor: extractor, Weights: weightsOf(manager.Networks[i])}
+			value := fitness(linear)
+			manager.Networks[i].Fitness = float32(value)
+			if value > bestFitness {
+				best, bestFitness = linear, value
+			}
+		}
+	}
+	return best, bestFitness
+}
+
+// weightsOf returns weights of the network as weights of the evaluator
+func weightsOf(network neural.Network) []float64 {
+	networkWeights := network.Weights()
+	weights := make([]float64, len(networkWeights))
+	for i, weight := range networkWeights {
+		weights[i] = float64(weight)
+	}
+	return weights
+}
@@ -0,0 +1,62 @@
+package eval
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wrutkowski/go1010/game"
+)
+
+// distance is the Fitness preferring weights close to 1, 2, 3...
+func distance(linear Linear) float64 {
+	total := 0.0
+	for i, weight := range linear.Weights {
+		total -= math.Abs(weight - float64(i+1))
+	}
+	return total
+}
+
+// dotExtractor has 7 features, fit count of the dot being the last one
+var dotExtractor = Extractor{Catalogue: game.NewCatalogue("dot", game.NewShape("dot", game.Red, "#"))}
+
+func TestCrossEntropyOptimize(t *testing.T) {
+	assert := assert.New(t)
+
+	start := Linear{Extractor: dotExtractor, Weights: []float64{1, 2}}
+	method := NewCrossEntropy()
+	method.Deviation = 5
+	method.Noise = 0.01
+	best, fitness := method.Optimize(start, distance)
+
+	assert.Equal(7, len(best.Weights))
+	assert.Equal(distance(best), fitness)
+	assert.True(fitness > -1, "fitness %f", fitness)
+
+	_, again := method.Optimize(start, distance)
+	assert.Equal(fitness, again, "same seed gives the same result")
+}
+
+func TestGeneticOptimize(t *testing.T) {
+	assert := assert.New(t)
+
+	method := Genetic{Population: 20, Generations: 5}
+	best, fitness := method.Optimize(dotExtractor, distance)
+
+	assert.Equal(7, len(best.Weights))
+	assert.Equal(distance(best), fitness)
+	assert.True(fitness > math.Inf(-1))
+}
+
+func TestGamesFitness(t *testing.T) {
+	assert := assert.New(t)
+
+	config := game.DefaultConfig()
+	config.MoveLimit = 5
+	fitness := GamesFitness([]int64{1, 2}, game.WithConfig(config))
+
+	value := fitness(DefaultLinear())
+	assert.True(value > 0)
+	assert.Equal(value, fitness(DefaultLinear()))
+	assert.Equal(0.0, GamesFitness(nil)(DefaultLinear()))
+}
@@ -0,0 +1,285 @@
+package game
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Hold is the BlockType of the hold slot, available when Config.Hold is
+// enabled. Block parked in the hold slot can be placed like any other block.
+const Hold BlockType = -1
+
+// Action is the kind of the Move
+type Action int
+
+// Action can be one of the following values
+const (
+	// PlaceBlock places the block at X,Y position of the board
+	PlaceBlock Action = 0
+	// HoldBlock swaps the block with the one in the hold slot, available when
+	// Config.Hold is enabled. X and Y are not used.
+	HoldBlock Action = 1
+	// RotateBlock rotates the block by 90 degrees clockwise, available when
+	// Config.Rotation is enabled and the rotated shape is in the catalogue.
+	// X and Y are not used.
+	RotateBlock Action = 2
+	// Reroll deals new blocks in place of all offered ones, available
+	// Config.Rerolls times in a game. Block, X and Y are not used.
+	Reroll Action = 3
+)
+
+// ActionLimit is the number of hold and rotate actions which can be made
+// before the next block is placed. Without the limit a player could swap and
+// rotate blocks forever, when it is reached only placements and rerolls are
+// possible.
+const ActionLimit = 16
+
+var actionNames = []string{"place", "hold", "rotate", "reroll"}
+
+// String returns lowercase name of the action
+func (action Action) String() string {
+	if action < 0 || int(action) >= len(actionNames) {
+		return fmt.Sprintf("Action(%d)", int(action))
+	}
+	return actionNames[action]
+}
+
+// MarshalText encodes Action as its name
+func (action Action) MarshalText() ([]byte, error) {
+	if action < 0 || int(action) >= len(actionNames) {
+		return nil, fmt.Errorf("Unknown action %d", int(action))
+	}
+	return []byte(action.String()), nil
+}
+
+// UnmarshalText decodes Action from its name
+func (action *Action) UnmarshalText(text []byte) error {
+	for value, name := range actionNames {
+		if name == strings.ToLower(string(text)) {
+			*action = Action(value)
+			return nil
+		}
+	}
+	return fmt.Errorf("Unknown action %q", string(text))
+}
+
+// Hold swaps the block with the one in the hold slot. New blocks are dealt
+// when the last offered block is parked. Error is returned when the hold
+// slot is not enabled or the block is empty.
+func (g *Game) Hold(block BlockType) error {
+	return g.Play(Move{Block: block, Action: HoldBlock})
+}
+
+// Rotate rotates the block by 90 degrees clockwise. Error is returned when
+// rotation is not enabled or the rotated shape is not in the catalogue.
+func (g *Game) Rotate(block BlockType) error {
+	return g.Play(Move{Block: block, Action: RotateBlock})
+}
+
+// Reroll replaces all offered blocks with new ones. Error is returned when
+// there are no rerolls left.
+func (g *Game) Reroll() error {
+	return g.Play(Move{Action: Reroll})
+}
+
+// RerollsLeft returns the number of rerolls which can still be used
+func (g Game) RerollsLeft() int {
+	return g.rerolls
+}
+
+// BlockTypes returns types of all blocks of the game, the hold slot being
+// the last one when it is enabled
+func (g Game) BlockTypes() []BlockType {
+	types := make([]BlockType, 0, len(g.Blocks)+1)
+	for i := range g.Blocks {
+		types = append(types, BlockType(i))
+	}
+	if g.Held != nil {
+		types = append(types, Hold)
+	}
+	return types
+}
+
+// Block returns container of the block, second value is false for incorrect
+// block type
+func (g Game) Block(block BlockType) ([][]BoardElement, bool) {
+	if block == Hold && g.Held != nil {
+		return g.Held, true
+	}
+	if block < 0 || int(block) >= len(g.Blocks) {
+		return nil, false
+	}
+	return g.Blocks[block], true
+}
+
+// setBlock replaces container of the block with a given type. Blocks are
+// copied first, value copies of the game share them until they are changed.
+func (g *Game) setBlock(block BlockType, container [][]BoardElement) {
+	if block == Hold {
+		g.Held = container
+		return
+	}
+	g.Blocks = append([][][]BoardElement(nil), g.Blocks...)
+	g.Blocks[block] = container
+}
+
+// LegalActions returns all moves other than placements which are possible:
+// holding and rotating each block and rerolling
+func (g Game) LegalActions() []Move {
+	var actions []Move
+	if g.GameOver {
+		return actions
+	}
+	for _, blockType := range g.BlockTypes() {
+		block, _ := g.Block(blockType)
+		if isBlockEmpty(block) {
+			continue
+		}
+		if g.actions >= ActionLimit {
+			continue
+		}
+		if g.config.Hold && blockType != Hold {
+			actions = append(actions, Move{Block: blockType, Action: HoldBlock})
+		}
+		if _, ok := g.rotatedBlock(block); ok {
+			actions = append(actions, Move{Block: blockType, Action: RotateBlock})
+		}
+	}
+	if g.rerolls > 0 {
+		actions = append(actions, Move{Action: Reroll})
+	}
+	return actions
+}
+
+// holdBlock swaps selected block with the one in the hold slot
+func (g *Game) holdBlock(block BlockType) error {
+	if !g.config.Hold {
+		return &ErrorGame{IncorrectAction, "Hold slot is not enabled"}
+	}
+	if block == Hold {
+		return &ErrorGame{IncorrectBlock, "Block in the hold slot cannot be held"}
+	}
+	if err := g.checkActionLimit(); err != nil {
+		return err
+	}
+	selected, err := g.selectedBlock(block)
+	if err != nil {
+		return err
+	}
+	g.setBlock(block, g.Held)
+	g.Held = selected
+	g.actions++
+	return nil
+}
+
+// rotateBlock replaces selected block with its rotation from the catalogue
+func (g *Game) rotateBlock(block BlockType) error {
+	if !g.config.Rotation {
+		return &ErrorGame{IncorrectAction, "Rotation is not enabled"}
+	}
+	if err := g.checkActionLimit(); err != nil {
+		return err
+	}
+	selected, err := g.selectedBlock(block)
+	if err != nil {
+		return err
+	}
+	rotated, ok := g.rotatedBlock(selected)
+	if !ok {
+		return &ErrorGame{IncorrectAction, "Rotated block is not in the catalogue"}
+	}
+	g.setBlock(block, rotated)
+	g.actions++
+	return nil
+}
+
+// checkActionLimit returns error when ActionLimit of hold and rotate actions
+// was reached since the last placement
+func (g Game) checkActionLimit() error {
+	if g.actions >= ActionLimit {
+		return &ErrorGame{IncorrectAction, fmt.Sprintf("Only %d hold and rotate actions can be made before placing a block", ActionLimit)}
+	}
+	return nil
+}
+
+// reroll deals new blocks in place of all offered ones
+func (g *Game) reroll() error {
+	if g.rerolls <= 0 {
+		return &ErrorGame{IncorrectAction, "There are no rerolls left"}
+	}
+	g.rerolls--
+	g.assignRandomBlocks()
+	return nil
+}
+
+// selectedBlock returns non empty block of a given type
+func (g Game) selectedBlock(block BlockType) ([][]BoardElement, error) {
+	selected, ok := g.Block(block)
+	if !ok {
+		return nil, &ErrorGame{IncorrectBlock, fmt.Sprintf("Incorrect block type specified (%d)", block)}
+	}
+	if isBlockEmpty(selected) {
+		return nil, &ErrorGame{IncorrectBlock, "Selected block is empty"}
+	}
+	return selected, nil
+}
+
+// rotatedBlock returns the block rotated by 90 degrees clockwise, second
+// value is false when rotation is not enabled, the rotated shape is not in
+// the catalogue or rotation does not change the block
+func (g Game) rotatedBlock(block [][]BoardElement) ([][]BoardElement, bool) {
+	if !g.config.Rotation {
+		return nil, false
+	}
+	shape := shapeFromGrid(block)
+	rotated := shape.Rotated()
+	if rotated.SameCells(shape) {
+		return nil, false
+	}
+	id := g.shapes.find(rotated)
+	if id < 0 {
+		return nil, false
+	}
+	return g.shapes.grid(id), true
+}
+
+// playableBlocks returns all blocks which can be placed before new blocks
+// are dealt: offered blocks, the held one and their rotations which can be
+// made before ActionLimit is reached
+func (g Game) playableBlocks() [][][]BoardElement {
+	var blocks [][][]BoardElement
+	for _, blockType := range g.BlockTypes() {
+		block, _ := g.Block(blockType)
+		if isBlockEmpty(block) {
+			continue
+		}
+		blocks = append(blocks, block)
+		for i := 0; i < 3 && g.actions+i < ActionLimit; i++ {
+			rotated, ok := g.rotatedBlock(block)
+			if !ok {
+				break
+			}
+			blocks = append(blocks, rotated)
+			block = rotated
+		}
+	}
+	return blocks
+}
+
+// canChangeBlocks checks if new blocks can be dealt without placing any
+// block: by a reroll or by parking the last block in the empty hold slot
+func (g Game) canChangeBlocks() bool {
+	if g.rerolls > 0 {
+		return true
+	}
+	if !g.config.Hold || !isBlockEmpty(g.Held) || g.actions >= ActionLimit {
+		return false
+	}
+	remaining := 0
+	for _, block := range g.Blocks {
+		if !isBlockEmpty(block) {
+			remaining++
+		}
+	}
+	return remaining == 1
+}
@@ -0,0 +1,262 @@
+package game
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func extendedConfig() Config {
+	config := DefaultConfig()
+	config.Hold = true
+	config.Rotation = true
+	config.Rerolls = 2
+	return config
+}
+
+func TestHold(t *testing.T) {
+	assert := assert.New(t)
+
+	g := NewWithOptions(WithConfig(extendedConfig()), WithHistory())
+	g.Blocks[A] = blockShape(3)
+	g.Blocks[B] = blockShape(9)
+	g.Blocks[C] = blockShape(0)
+
+	assert.Equal([]BlockType{A, B, C, Hold}, g.BlockTypes())
+	assert.True(isBlockEmpty(g.Held))
+	assert.Equal(IncorrectBlock, g.Hold(Hold).(*ErrorGame).Reason)
+	g.GameOver = false
+
+	assert.Nil(g.Hold(A))
+	assert.Equal(blockShape(3), g.Held)
+	assert.True(isBlockEmpty(g.Blocks[A]))
+	assert.Nil(g.Hold(B))
+	assert.Equal(blockShape(9), g.Held)
+	assert.Equal(blockShape(3), g.Blocks[B])
+
+	assert.True(g.CanPlace(Hold, 0, 0))
+	assert.Nil(g.Move(Hold, 0, 0))
+	assert.True(isBlockEmpty(g.Held))
+	assert.Equal(4, g.Score)
+	assert.Equal([]Move{{A, 0, 0, HoldBlock}, {B, 0, 0, HoldBlock}, {Hold, 0, 0, PlaceBlock}}, g.Moves())
+
+	// parking the last block deals new blocks
+	assert.Nil(g.Move(B, 5, 5))
+	assert.Nil(g.Hold(C))
+	assert.Equal(blockShape(0), g.Held)
+	assert.False(isBlockEmpty(g.Blocks[A]))
+
+	assert.Nil(g.Undo())
+	assert.Equal(blockShape(0), g.Blocks[C])
+	assert.True(isBlockEmpty(g.Held))
+
+	g = New()
+	assert.Nil(g.Held)
+	assert.Equal(IncorrectAction, g.Hold(A).(*ErrorGame).Reason)
+	assert.True(g.GameOver)
+}
+
+func TestActionLimit(t *testing.T) {
+	assert := assert.New(t)
+
+	// player cycling hold and rotate without placing blocks
+	cycle := func(g *Game) int {
+		actions := 0
+		for ; !g.GameOver && actions < 1000; actions++ {
+			legal := g.LegalActions()
+			if len(legal) == 0 || legal[0].Action == Reroll {
+				break
+			}
+			g.Play(legal[0])
+		}
+		return actions
+	}
+
+	g := NewWithOptions(WithConfig(extendedConfig()))
+	assert.Equal(ActionLimit, cycle(&g))
+	assert.Equal([]Move{{Action: Reroll}}, g.LegalActions(), "only placements and rerolls are left")
+	assert.Equal(IncorrectAction, g.Hold(A).(*ErrorGame).Reason)
+	assert.True(g.GameOver, "invalid move ends the game")
+
+	g = NewWithOptions(WithConfig(extendedConfig()))
+	cycle(&g)
+	moves := g.LegalMoves()
+	assert.Nil(g.Move(moves[0].Block, moves[0].X, moves[0].Y))
+	assert.NotEqual(Reroll, g.LegalActions()[0].Action, "placement allows new actions")
+
+	// game is over when blocks fit only after rotations which exceed the
+	// limit
+	config := extendedConfig()
+	config.Hold = false
+	config.Rerolls = 0
+	g = NewWithOptions(WithConfig(config))
+	for x := 0; x < 10; x++ {
+		for y := 0; y < 10; y++ {
+			if x > 0 || y > 2 {
+				g.Board[x][y] = Green
+			}
+		}
+	}
+	g.Blocks[A] = blockShape(4)
+	g.Blocks[B] = createContainer(5)
+	g.Blocks[C] = createContainer(5)
+	assert.False(g.isGameOver())
+	g.actions = ActionLimit
+	assert.True(g.isGameOver())
+}
+
+func TestRotate(t *testing.T) {
+	assert := assert.New(t)
+
+	g := NewWithOptions(WithConfig(extendedConfig()))
+	g.Blocks[A] = blockShape(5)
+	g.Blocks[B] = blockShape(9)
+	g.Blocks[C] = blockShape(11)
+
+	assert.Nil(g.Rotate(A))
+	assert.Equal(blockShape(6), g.Blocks[A])
+	assert.Nil(g.Rotate(A))
+	assert.Equal(blockShape(5), g.Blocks[A])
+	assert.Nil(g.Rotate(C))
+	assert.Equal(blockShape(12), g.Blocks[C])
+	assert.Equal(IncorrectAction, g.Rotate(B).(*ErrorGame).Reason, "square does not change when rotated")
+	g.GameOver = false
+
+	// rotated shape must be in the catalogue
+	catalogue := NewCatalogue("L", NewShape("L", Red, "#.", "#.", "##"))
+	g = NewWithOptions(WithConfig(extendedConfig()), WithCatalogue(catalogue))
+	assert.Equal(IncorrectAction, g.Rotate(A).(*ErrorGame).Reason)
+
+	g = New()
+	assert.Equal(IncorrectAction, g.Rotate(A).(*ErrorGame).Reason)
+}
+
+func TestReroll(t *testing.T) {
+	assert := assert.New(t)
+
+	g := NewWithOptions(WithConfig(extendedConfig()))
+	blocks := g.Clone().Blocks
+	assert.Equal(2, g.RerollsLeft())
+
+	next, result, err := g.Simulate(Move{Action: Reroll})
+	assert.Nil(err)
+	assert.True(result.BlocksDealt)
+	assert.Equal(0, result.ScoreDelta)
+	assert.NotEqual(blocks, next.Blocks)
+	assert.Equal(1, next.RerollsLeft())
+	assert.Equal(2, g.RerollsLeft())
+
+	assert.Nil(g.Reroll())
+	assert.Equal(next.Blocks, g.Blocks)
+	assert.Nil(g.Reroll())
+	assert.Equal(IncorrectAction, g.Reroll().(*ErrorGame).Reason)
+	assert.Equal(0, g.RerollsLeft())
+}
+
+func TestLegalActions(t *testing.T) {
+	assert := assert.New(t)
+
+	g := NewWithOptions(WithConfig(extendedConfig()))
+	g.Blocks[A] = blockShape(0)
+	g.Blocks[B] = blockShape(13)
+	g.Blocks[C] = createContainer(5)
+
+	assert.Equal([]Move{
+		{A, 0, 0, HoldBlock},
+		{B, 0, 0, HoldBlock},
+		{B, 0, 0, RotateBlock},
+		{0, 0, 0, Reroll},
+	}, g.LegalActions())
+
+	g.Hold(B)
+	moves := g.LegalMoves()
+	assert.Equal(Move{Hold, 7, 7, PlaceBlock}, moves[len(moves)-1])
+}
+
+func TestGameOverWithExtensions(t *testing.T) {
+	assert := assert.New(t)
+
+	// only vertical line of four fits on the board
+	fill := func(g *Game) {
+		for x := 0; x < 10; x++ {
+			for y := 0; y < 10; y++ {
+				g.Board[x][y] = Green
+			}
+		}
+		for x := 0; x < 4; x++ {
+			g.Board[x][5] = None
+		}
+	}
+
+	for _, engine := range []Engine{SliceEngine, BitboardEngine} {
+		config := DefaultConfig()
+		config.Engine = engine
+		g := NewWithOptions(WithConfig(config))
+		fill(&g)
+		g.Blocks[A] = blockShape(5)
+		g.Blocks[B] = createContainer(5)
+		g.Blocks[C] = createContainer(5)
+		assert.True(g.isGameOver())
+
+		config.Rotation = true
+		g = NewWithOptions(WithConfig(config))
+		fill(&g)
+		g.Blocks[A] = blockShape(5)
+		g.Blocks[B] = createContainer(5)
+		g.Blocks[C] = createContainer(5)
+		assert.False(g.isGameOver(), "rotated block fits")
+
+		config.Rotation = false
+		config.Hold = true
+		g = NewWithOptions(WithConfig(config))
+		fill(&g)
+		g.Blocks[A] = blockShape(5)
+		g.Blocks[B] = createContainer(5)
+		g.Blocks[C] = createContainer(5)
+		assert.False(g.isGameOver(), "last block can be parked")
+		g.Held = blockShape(9)
+		assert.True(g.isGameOver())
+		g.Held = blockShape(6)
+		assert.False(g.isGameOver(), "held block fits")
+
+		config.Hold = false
+		config.Rerolls = 1
+		g = NewWithOptions(WithConfig(config))
+		fill(&g)
+		g.Blocks[A] = blockShape(5)
+		assert.False(g.isGameOver(), "blocks can be rerolled")
+	}
+}
+
+func TestExtensionsSerialization(t *testing.T) {
+	assert := assert.New(t)
+
+	g := NewWithOptions(WithConfig(extendedConfig()))
+	g.Hold(A)
+	g.Reroll()
+
+	data, err := json.Marshal(g)
+	assert.Nil(err)
+	var decoded Game
+	assert.Nil(json.Unmarshal(data, &decoded))
+	assert.Equal(g.Held, decoded.Held)
+	assert.Equal(g.Blocks, decoded.Blocks)
+	assert.Equal(1, decoded.RerollsLeft())
+	assert.Equal(g.LegalActions(), decoded.LegalActions())
+
+	parsed, err := ParseNotation(g.Notation())
+	assert.Nil(err)
+	assert.Equal(g.Notation(), parsed.Notation())
+	assert.Equal(g.Held, parsed.Held)
+	assert.True(parsed.Config().Hold)
+
+	data, err = json.Marshal(Move{B, 1, 2, RotateBlock})
+	assert.Nil(err)
+	assert.Equal(`{"block":1,"x":1,"y":2,"action":"rotate"}`, string(data))
+	data, _ = json.Marshal(Move{B, 1, 2, PlaceBlock})
+	assert.Equal(`{"block":1,"x":1,"y":2}`, string(data))
+	var move Move
+	assert.Nil(json.Unmarshal([]byte(`{"block":-1,"x":0,"y":0,"action":"hold"}`), &move))
+	assert.Equal(Move{Hold, 0, 0, HoldBlock}, move)
+}
@@ -0,0 +1,404 @@
+package game
+
+import (
+	"fmt"
+	"math/bits"
+	"sync"
+)
+
+// Engine decides how the board is represented while moves are checked and
+// made. All engines give the same results, they differ in speed.
+type Engine int
+
+// Engine can be one of the following values
+const (
+	// SliceEngine works directly on the Board slices
+	SliceEngine Engine = 0
+	// BitboardEngine keeps the board as 128-bit masks between moves, it is
+	// available for boards with at most 128 cells. Board changed outside of
+	// moves has to be assigned as a new slice, changes made in place are not
+	// noticed.
+	BitboardEngine Engine = 1
+)
+
+// bitboardCells is the maximal number of cells of the board supported by
+// BitboardEngine
+const bitboardCells = 128
+
+// bitboard is a set of board cells, cell x,y is stored in bit x*columns+y
+type bitboard struct {
+	low  uint64
+	high uint64
+}
+
+func (b bitboard) and(other bitboard) bitboard {
+	return bitboard{b.low & other.low, b.high & other.high}
+}
+
+func (b bitboard) or(other bitboard) bitboard {
+	return bitboard{b.low | other.low, b.high | other.high}
+}
+
+func (b bitboard) andNot(other bitboard) bitboard {
+	return bitboard{b.low &^ other.low, b.high &^ other.high}
+}
+
+func (b bitboard) isEmpty() bool {
+	return b.low == 0 && b.high == 0
+}
+
+func (b bitboard) count() int {
+	return bits.OnesCount64(b.low) + bits.OnesCount64(b.high)
+}
+
+func (b bitboard) has(index int) bool {
+	if index < 64 {
+		return b.low&(1<<uint(index)) != 0
+	}
+	return b.high&(1<<uint(index-64)) != 0
+}
+
+func (b bitboard) with(index int) bitboard {
+	if index < 64 {
+		b.low |= 1 << uint(index)
+	} else {
+		b.high |= 1 << uint(index-64)
+	}
+	return b
+}
+
+func (b bitboard) shiftLeft(n int) bitboard {
+	switch {
+	case n == 0:
+		return b
+	case n >= 128:
+		return bitboard{}
+	case n >= 64:
+		return bitboard{0, b.low << uint(n-64)}
+	}
+	return bitboard{b.low << uint(n), b.high<<uint(n) | b.low>>uint(64-n)}
+}
+
+// boardMasks holds masks precomputed for a board of a given size and the
+// catalogue of shapes dealt in the game
+type boardMasks struct {
+	rows        int
+	columns     int
+	rowMasks    []bitboard
+	columnMasks []bitboard
+	// shapes holds masks of the catalogue shapes by gridKey of their
+	// containers
+	shapes map[uint32]shapeMask
+}
+
+// boardMasksKey identifies masks shared by games with the same board size
+// and catalogue
+type boardMasksKey struct {
+	rows      int
+	columns   int
+	catalogue *Catalogue
+}
+
+var (
+	boardMasksCache      = make(map[boardMasksKey]*boardMasks)
+	boardMasksCacheMutex sync.Mutex
+)
+
+// masksForBoard returns masks of rows, columns and catalogue shapes for the
+// board of a given size, masks are computed once and shared by all games
+func masksForBoard(rows int, columns int, catalogue *Catalogue) *boardMasks {
+	if rows*columns > bitboardCells {
+		panic(fmt.Sprintf("Board %dx%d is too big for bitboard", rows, columns))
+	}
+
+	boardMasksCacheMutex.Lock()
+	defer boardMasksCacheMutex.Unlock()
+
+	key := boardMasksKey{rows, columns, catalogue}
+	if masks, ok := boardMasksCache[key]; ok {
+		return masks
+	}
+
+	masks := &boardMasks{
+		rows:        rows,
+		columns:     columns,
+		rowMasks:    make([]bitboard, rows),
+		columnMasks: make([]bitboard, columns),
+		shapes:      make(map[uint32]shapeMask),
+	}
+	for x := 0; x < rows; x++ {
+		for y := 0; y < columns; y++ {
+			masks.rowMasks[x] = masks.rowMasks[x].with(x*columns + y)
+			masks.columnMasks[y] = masks.columnMasks[y].with(x*columns + y)
+		}
+	}
+	for _, shape := range catalogue.Shapes {
+		grid := shape.Grid()
+		if key, ok := gridKey(grid); ok {
+			if mask, ok := masks.convertBlock(grid); ok {
+				masks.shapes[key] = mask
+			}
+		}
+	}
+	boardMasksCache[key] = masks
+	return masks
+}
+
+// bitboardState is the board of a game using BitboardEngine. It is kept
+// between moves, so that the Board slices are not scanned again.
+type bitboardState struct {
+	filled bitboard
+	stones bitboard
+	// board is the first row of the Board the state describes, Board
+	// replaced outside of moves is converted again
+	board *[]BoardElement
+}
+
+// state returns bitboards of filled cells and stones of the board
+func (masks *boardMasks) state(board [][]BoardElement) bitboardState {
+	var state bitboardState
+	for x := 0; x < masks.rows; x++ {
+		for y := 0; y < masks.columns; y++ {
+			switch board[x][y] {
+			case None:
+			case Stone:
+				state.stones = state.stones.with(x*masks.columns + y)
+				fallthrough
+			default:
+				state.filled = state.filled.with(x*masks.columns + y)
+			}
+		}
+	}
+	state.board = &board[0]
+	return state
+}
+
+// boardState returns bitboards of the Board, they are computed again only
+// when the Board was replaced since the last move
+func (g *Game) boardState() bitboardState {
+	if g.bits.board != &g.Board[0] {
+		g.bits = g.masks.state(g.Board)
+	}
+	return g.bits
+}
+
+// copyRows returns board sharing rows with a given one, only rows set in
+// changed, row x being stored in bit x, are copied, all of them to one array.
+// Rows are never changed in place, so value copies of the game keep their
+// boards.
+func (masks *boardMasks) copyRows(board [][]BoardElement, changed bitboard) [][]BoardElement {
+	copied := make([][]BoardElement, masks.rows)
+	cells := make([]BoardElement, changed.count()*masks.columns)
+	for x := range copied {
+		if !changed.has(x) {
+			copied[x] = board[x]
+			continue
+		}
+		copied[x], cells = cells[:masks.columns:masks.columns], cells[masks.columns:]
+		copy(copied[x], board[x])
+	}
+	return copied
+}
+
+// shapeMask is a block placed at 0,0 position of the board together with
+// its size, placing at x,y is a shift by x*columns+y bits
+type shapeMask struct {
+	bits    bitboard
+	rows    int
+	columns int
+}
+
+// gridKey returns filled cells of the block container as bits, x,y cell
+// being stored in bit x*blockSize+y. Second value is false for containers
+// bigger than blockSize.
+func gridKey(block [][]BoardElement) (uint32, bool) {
+	if len(block) > blockSize {
+		return 0, false
+	}
+	var key uint32
+	for x, row := range block {
+		if len(row) > blockSize {
+			return 0, false
+		}
+		for y, element := range row {
+			if element != None {
+				key |= 1 << uint(x*blockSize+y)
+			}
+		}
+	}
+	return key, true
+}
+
+// blockMask returns mask of the block, masks of the catalogue shapes are
+// precomputed and only other blocks are converted. Second value is false
+// when the block is empty or does not fit on the board at all.
+func (masks *boardMasks) blockMask(block [][]BoardElement) (shapeMask, bool) {
+	if key, ok := gridKey(block); ok {
+		if key == 0 {
+			return shapeMask{}, false
+		}
+		if mask, ok := masks.shapes[key]; ok {
+			return mask, true
+		}
+	}
+	return masks.convertBlock(block)
+}
+
+// convertBlock returns mask of filled cells of the block
+func (masks *boardMasks) convertBlock(block [][]BoardElement) (shapeMask, bool) {
+	var mask shapeMask
+	for x := range block {
+		for y := range block[x] {
+			if block[x][y] == None {
+				continue
+			}
+			if x >= masks.rows || y >= masks.columns {
+				return mask, false
+			}
+			mask.bits = mask.bits.with(x*masks.columns + y)
+			if x+1 > mask.rows {
+				mask.rows = x + 1
+			}
+			if y+1 > mask.columns {
+				mask.columns = y + 1
+			}
+		}
+	}
+	return mask, !mask.bits.isEmpty()
+}
+
+// fits checks if the block can be placed at x,y position
+func (masks *boardMasks) fits(board bitboard, mask shapeMask, x int, y int) bool {
+	if x < 0 || y < 0 || x+mask.rows > masks.rows || y+mask.columns > masks.columns {
+		return false
+	}
+	return mask.bits.shiftLeft(x*masks.columns + y).and(board).isEmpty()
+}
+
+// placeBlockBitboard does the same as placeBlock using bitboard to check the
+// placement and full lanes. Board is replaced with a new one sharing rows
+// which are not changed by the move, so that value copies of the game keep
+// their boards.
+func (g *Game) placeBlockBitboard(x int, y int, block [][]BoardElement) (MoveResult, error) {
+	var result MoveResult
+	if x < 0 || y < 0 {
+		return result, &ErrorGame{IncorrectPosition, fmt.Sprintf("%d,%d is below 0,0", x, y)}
+	}
+
+	state := g.boardState()
+	mask, ok := g.masks.blockMask(block)
+	if !ok || !g.masks.fits(state.filled, mask, x, y) {
+		return result, &ErrorGame{IncorrectPosition, fmt.Sprintf("Block cannot be placed at %d,%d", x, y)}
+	}
+	result.CellsPlaced = mask.bits.count()
+	filled := state.filled.or(mask.bits.shiftLeft(x*g.masks.columns + y))
+
+	// check all full rows and columns before removing anything, lanes made
+	// only of stones are not full
+	var cleared bitboard
+	full := func(lane bitboard) bool {
+		return filled.and(lane) == lane && state.stones.and(lane) != lane
+	}
+	if !g.config.KeepRows {
+		for row, rowMask := range g.masks.rowMasks {
+			if full(rowMask) {
+				result.RowsCleared = append(result.RowsCleared, row)
+				cleared = cleared.or(rowMask)
+			}
+		}
+	}
+	if !g.config.KeepColumns {
+		for column, columnMask := range g.masks.columnMasks {
+			if full(columnMask) {
+				result.ColumnsCleared = append(result.ColumnsCleared, column)
+				cleared = cleared.or(columnMask)
+			}
+		}
+	}
+	cleared = cleared.andNot(state.stones)
+
+	var changed bitboard
+	for row := 0; row < g.masks.rows; row++ {
+		if row >= x && row < x+mask.rows || !cleared.and(g.masks.rowMasks[row]).isEmpty() {
+			changed = changed.with(row)
+		}
+	}
+	board := g.masks.copyRows(g.Board, changed)
+	for blockX := 0; blockX < mask.rows; blockX++ {
+		for blockY := 0; blockY < mask.columns; blockY++ {
+			if block[blockX][blockY] != None {
+				board[x+blockX][y+blockY] = block[blockX][blockY]
+			}
+		}
+	}
+	for row := 0; row < g.masks.rows && !cleared.isEmpty(); row++ {
+		if cleared.and(g.masks.rowMasks[row]).isEmpty() {
+			continue
+		}
+		for column := 0; column < g.masks.columns; column++ {
+			if cleared.has(row*g.masks.columns + column) {
+				board[row][column] = None
+			}
+		}
+	}
+
+	g.Board = board
+	g.bits = bitboardState{filled: filled.andNot(cleared), stones: state.stones, board: &board[0]}
+	return result, nil
+}
+
+// positions returns set of x,y positions, stored in bit x*columns+y, where
+// the block fits on the board
+func (masks *boardMasks) positions(board bitboard, mask shapeMask) bitboard {
+	var positions bitboard
+	for x := 0; x+mask.rows <= masks.rows; x++ {
+		for y := 0; y+mask.columns <= masks.columns; y++ {
+			if mask.bits.shiftLeft(x*masks.columns + y).and(board).isEmpty() {
+				positions = positions.with(x*masks.columns + y)
+			}
+		}
+	}
+	return positions
+}
+
+// firstPosition returns the first position, ordered by x and y, where the
+// block fits on the board, third value is false when there is none
+func (masks *boardMasks) firstPosition(board bitboard, mask shapeMask) (int, int, bool) {
+	for x := 0; x+mask.rows <= masks.rows; x++ {
+		for y := 0; y+mask.columns <= masks.columns; y++ {
+			if mask.bits.shiftLeft(x*masks.columns + y).and(board).isEmpty() {
+				return x, y, true
+			}
+		}
+	}
+	return 0, 0, false
+}
+
+// legalMovesBitboard does the same as LegalMoves using bitboard. Positions
+// of all blocks are found first, so that moves are allocated once.
+func (g Game) legalMovesBitboard() []Move {
+	board := g.boardState().filled
+	blockTypes := g.BlockTypes()
+	positions := make([]bitboard, len(blockTypes))
+	count := 0
+	for i, blockType := range blockTypes {
+		block, _ := g.Block(blockType)
+		if mask, ok := g.masks.blockMask(block); ok {
+			positions[i] = g.masks.positions(board, mask)
+			count += positions[i].count()
+		}
+	}
+	if count == 0 {
+		return nil
+	}
+
+	moves := make([]Move, 0, count)
+	for i, blockType := range blockTypes {
+		for index := 0; index < g.masks.rows*g.masks.columns; index++ {
+			if positions[i].has(index) {
+				moves = append(moves, Move{blockType, index / g.masks.columns, index % g.masks.columns, PlaceBlock})
+			}
+		}
+	}
+	return moves
+}
@@ -0,0 +1,246 @@
+package game
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBitboardShiftLeft(t *testing.T) {
+	assert := assert.New(t)
+
+	b := bitboard{}.with(0).with(63)
+
+	assert.Equal(bitboard{1 << 5, 1 << 4}, b.shiftLeft(5))
+	assert.Equal(bitboard{0, 1 | 1<<63}, b.shiftLeft(64))
+	assert.Equal(bitboard{}, b.shiftLeft(128))
+	assert.True(b.shiftLeft(100).has(100))
+	assert.Equal(2, b.shiftLeft(1).count())
+}
+
+func TestBoardMasks(t *testing.T) {
+	assert := assert.New(t)
+
+	masks := masksForBoard(12, 9, defaultCatalogue)
+
+	assert.Equal(masks, masksForBoard(12, 9, defaultCatalogue), "masks must be cached")
+	assert.Equal(defaultCatalogue.Len(), len(masks.shapes), "masks of shapes must be precomputed")
+	assert.Equal(12, len(masks.rowMasks))
+	assert.Equal(9, len(masks.columnMasks))
+	assert.Equal(9, masks.rowMasks[11].count())
+	assert.True(masks.rowMasks[11].has(107))
+	assert.Equal(12, masks.columnMasks[0].count())
+
+	mask, ok := masks.blockMask(blockShape(13))
+	assert.True(ok)
+	assert.Equal(3, mask.rows)
+	assert.Equal(3, mask.columns)
+	assert.True(masks.fits(bitboard{}, mask, 9, 6))
+	assert.False(masks.fits(bitboard{}, mask, 10, 6))
+	assert.False(masks.fits(bitboard{}, mask, 9, 7))
+	assert.False(masks.fits(bitboard{}.with(9*9+8), mask, 9, 6))
+	assert.True(masks.fits(bitboard{}.with(9*9+6), mask, 9, 6))
+
+	_, ok = masks.blockMask(createContainer(5))
+	assert.False(ok)
+	_, ok = masksForBoard(2, 2, defaultCatalogue).blockMask(blockShape(3))
+	assert.False(ok)
+}
+
+func TestBitboardEngineFallback(t *testing.T) {
+	assert := assert.New(t)
+
+	config := DefaultConfig()
+	config.Engine = BitboardEngine
+	config.Rows = 12
+	config.Columns = 12
+
+	g := NewWithOptions(WithConfig(config))
+	assert.Equal(SliceEngine, g.Config().Engine)
+}
+
+// TestBitboardEngineMatchesSliceEngine plays the same random games with both
+// engines and compares them after every move
+func TestBitboardEngineMatchesSliceEngine(t *testing.T) {
+	assert := assert.New(t)
+
+	configs := []Config{DefaultConfig(), {Rows: 8, Columns: 8, Blocks: 3, KeepColumns: true}, {Rows: 12, Columns: 9, Blocks: 2, KeepRows: true}}
+	for _, config := range configs {
+		for seed := int64(0); seed < 10; seed++ {
+			bitboardConfig := config
+			bitboardConfig.Engine = BitboardEngine
+			sliceGame := NewWithOptions(WithSeed(seed), WithConfig(config))
+			bitboardGame := NewWithOptions(WithSeed(seed), WithConfig(bitboardConfig))
+			random := rand.New(rand.NewSource(seed))
+
+			for !sliceGame.GameOver {
+				moves := sliceGame.LegalMoves()
+				if !assert.Equal(moves, bitboardGame.LegalMoves()) {
+					return
+				}
+				move := moves[random.Intn(len(moves))]
+				sliceResult, sliceError := sliceGame.play(move)
+				bitboardResult, bitboardError := bitboardGame.play(move)
+				assert.Equal(sliceError, bitboardError)
+				assert.Equal(sliceResult, bitboardResult)
+				assert.Equal(sliceGame.Board, bitboardGame.Board)
+				assert.Equal(sliceGame.Score, bitboardGame.Score)
+				assert.Equal(sliceGame.GameOver, bitboardGame.GameOver)
+			}
+		}
+	}
+}
+
+func TestBitboardEngineInvalidMove(t *testing.T) {
+	assert := assert.New(t)
+
+	config := DefaultConfig()
+	config.Engine = BitboardEngine
+	g := NewWithOptions(WithConfig(config))
+	g.Blocks[A] = blockShape(8)
+	for x := 5; x < 10; x++ {
+		g.Board[x][0] = Red
+	}
+
+	assert.Equal(IncorrectPosition, g.Move(A, 1, 0).(*ErrorGame).Reason)
+	g.GameOver = false
+	assert.Equal(IncorrectPosition, g.Move(A, 6, 1).(*ErrorGame).Reason)
+	g.GameOver = false
+	assert.Equal(IncorrectPosition, g.Move(A, -1, 1).(*ErrorGame).Reason)
+	g.GameOver = false
+	assert.Nil(g.Move(A, 0, 0))
+	assert.Equal(15, g.Score)
+	assert.Equal(None, g.Board[9][0], "full column is removed")
+}
+
+func TestBitboardEngineValueCopy(t *testing.T) {
+	assert := assert.New(t)
+
+	config := DefaultConfig()
+	config.Engine = BitboardEngine
+	g := NewWithOptions(WithConfig(config))
+	g.Blocks[A] = blockShape(9)
+	board := cloneContainer(g.Board)
+
+	c := g
+	assert.Nil(c.Move(A, 0, 0))
+	assert.Equal(board, g.Board, "move on a copy must not change the original board")
+	assert.Equal(Cyan, c.Board[1][1])
+	assert.True(&g.Board[9][0] == &c.Board[9][0], "rows not changed by the move are shared")
+	assert.True(g.CanPlace(A, 0, 0))
+	assert.False(c.CanPlace(B, 1, 1))
+}
+
+func TestBitboardEngineBoardReplaced(t *testing.T) {
+	assert := assert.New(t)
+
+	config := DefaultConfig()
+	config.Engine = BitboardEngine
+	g := NewWithOptions(WithConfig(config))
+	g.Blocks[A] = blockShape(0)
+	assert.Nil(g.Move(A, 0, 0))
+
+	board := createBoard(10, 10)
+	for y := 1; y < 10; y++ {
+		board[4][y] = Red
+	}
+	g.Board = board
+	g.Blocks[B] = blockShape(0)
+	assert.False(g.CanPlace(B, 4, 1))
+	result, err := g.play(Move{B, 4, 0, PlaceBlock})
+	assert.Nil(err)
+	assert.Equal([]int{4}, result.RowsCleared)
+	assert.Equal(createBoard(10, 10), g.Board)
+}
+
+func benchmarkEngine(b *testing.B, engine Engine) {
+	config := DefaultConfig()
+	config.Engine = engine
+	for i := 0; i < b.N; i++ {
+		g := NewWithOptions(WithSeed(int64(i%100)), WithConfig(config))
+		for !g.GameOver {
+			moves := g.LegalMoves()
+			move := moves[len(moves)/2]
+			g.Move(move.Block, move.X, move.Y)
+		}
+	}
+}
+
+func BenchmarkSliceEngine(b *testing.B) {
+	benchmarkEngine(b, SliceEngine)
+}
+
+func BenchmarkBitboardEngine(b *testing.B) {
+	benchmarkEngine(b, BitboardEngine)
+}
+
+// benchmarkPlacement places blocks of a played game on boards from before
+// each move, so that only the placement is measured
+func benchmarkPlacement(b *testing.B, place func(g *Game, move Move, block [][]BoardElement) (MoveResult, error)) {
+	config := DefaultConfig()
+	config.Engine = BitboardEngine
+	var states []Game
+	var moves []Move
+	for g := NewWithOptions(WithConfig(config)); !g.GameOver; {
+		legal := g.LegalMoves()
+		move := legal[len(legal)/2]
+		g.boardState()
+		states = append(states, g)
+		moves = append(moves, move)
+		g.Move(move.Block, move.X, move.Y)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		g := states[i%len(states)]
+		move := moves[i%len(moves)]
+		if _, err := place(&g, move, g.Blocks[move.Block]); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkPlaceBlock measures placement of the original game, which is
+// also used by SliceEngine
+func BenchmarkPlaceBlock(b *testing.B) {
+	benchmarkPlacement(b, func(g *Game, move Move, block [][]BoardElement) (MoveResult, error) {
+		return g.placeBlock(move.X, move.Y, block)
+	})
+}
+
+func BenchmarkPlaceBlockBitboard(b *testing.B) {
+	benchmarkPlacement(b, func(g *Game, move Move, block [][]BoardElement) (MoveResult, error) {
+		return g.placeBlockBitboard(move.X, move.Y, block)
+	})
+}
+
+// benchmarkGameOver checks the game over after every move of a played game,
+// so that the board changes between checks in the same way as in games
+func benchmarkGameOver(b *testing.B, engine Engine) {
+	config := DefaultConfig()
+	config.Engine = engine
+	var states []Game
+	for g := NewWithOptions(WithConfig(config)); !g.GameOver; {
+		moves := g.LegalMoves()
+		move := moves[len(moves)/2]
+		g.Move(move.Block, move.X, move.Y)
+		states = append(states, g)
+	}
+
+	g := states[0]
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		state := states[i%len(states)]
+		g.Board, g.Blocks, g.bits = state.Board, state.Blocks, state.bits
+		g.isGameOver()
+	}
+}
+
+func BenchmarkSliceEngineGameOver(b *testing.B) {
+	benchmarkGameOver(b, SliceEngine)
+}
+
+func BenchmarkBitboardEngineGameOver(b *testing.B) {
+	benchmarkGameOver(b, BitboardEngine)
+}
@@ -0,0 +1,256 @@
+package game
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Catalogue is a registry of shapes which can be dealt in the game. Shape ID
+// is equal to its index in Shapes.
+type Catalogue struct {
+	Name   string  `json:"name"`
+	Shapes []Shape `json:"shapes"`
+
+	// offsets of the shapes by gridKey of their containers
+	offsets map[uint32]blockOffsets
+}
+
+// defaultCatalogue holds 19 shapes of the original game
+var defaultCatalogue = NewCatalogue("1010",
+	NewShape("dot", Red, "#"),
+	NewShape("two horizontal", Green, "##"),
+	NewShape("two vertical", Green, "#", "#"),
+	NewShape("three horizontal", Yellow, "###"),
+	NewShape("three vertical", Yellow, "#", "#", "#"),
+	NewShape("four horizontal", Blue, "####"),
+	NewShape("four vertical", Blue, "#", "#", "#", "#"),
+	NewShape("five horizontal", Magenta, "#####"),
+	NewShape("five vertical", Magenta, "#", "#", "#", "#", "#"),
+	NewShape("square two", Cyan, "##", "##"),
+	NewShape("square three", White, "###", "###", "###"),
+	NewShape("big corner top left", Cyan, "###", "#..", "#.."),
+	NewShape("big corner top right", Cyan, "###", "..#", "..#"),
+	NewShape("big corner bottom right", Cyan, "..#", "..#", "###"),
+	NewShape("big corner bottom left", Cyan, "#..", "#..", "###"),
+	NewShape("corner top left", White, "##", "#."),
+	NewShape("corner top right", White, "##", ".#"),
+	NewShape("corner bottom right", White, ".#", "##"),
+	NewShape("corner bottom left", White, "#.", "##"))
+
+// DefaultCatalogue returns catalogue with 19 shapes of the original game
+func DefaultCatalogue() *Catalogue {
+	return defaultCatalogue
+}
+
+// NewCatalogue returns catalogue containing provided shapes, IDs of the
+// shapes are reassigned to match their position
+func NewCatalogue(name string, shapes ...Shape) *Catalogue {
+	catalogue := &Catalogue{Name: name}
+	for _, shape := range shapes {
+		catalogue.add(shape)
+	}
+	return catalogue
+}
+
+// Len returns number of shapes in the catalogue
+func (catalogue *Catalogue) Len() int {
+	return len(catalogue.Shapes)
+}
+
+// Shape returns shape with given ID, second value is false when there is no
+// such shape
+func (catalogue *Catalogue) Shape(id int) (Shape, bool) {
+	if id < 0 || id >= len(catalogue.Shapes) {
+		return Shape{}, false
+	}
+	return catalogue.Shapes[id], true
+}
+
+// GridSize returns size of the biggest container returned by Grid for the
+// shapes of the catalogue, it is at least 5
+func (catalogue *Catalogue) GridSize() int {
+	size := blockSize
+	for _, shape := range catalogue.Shapes {
+		rows, columns := shape.Size()
+		if rows > size {
+			size = rows
+		}
+		if columns > size {
+			size = columns
+		}
+	}
+	return size
+}
+
+// Find returns ID of the shape occupying the same cells as provided grid,
+// -1 is returned when there is no such shape in the catalogue
+func (catalogue *Catalogue) Find(grid [][]BoardElement) int {
+	return catalogue.find(shapeFromGrid(grid))
+}
+
+// find returns ID of the shape occupying the same cells as provided shape
+func (catalogue *Catalogue) find(shape Shape) int {
+	for _, candidate := range catalogue.Shapes {
+		if candidate.SameCells(shape) {
+			return candidate.ID
+		}
+	}
+	return -1
+}
+
+// WithRotations returns new catalogue extended with all rotations of its
+// shapes which are not already in the catalogue
+func (catalogue *Catalogue) WithRotations() *Catalogue {
+	extended := NewCatalogue(catalogue.Name, catalogue.Shapes...)
+	for _, shape := range catalogue.Shapes {
+		rotated := shape
+		for angle := 90; angle < 360; angle += 90 {
+			rotated = rotated.Rotated()
+			rotated.Name = fmt.Sprintf("%s r%d", shape.Name, angle)
+			extended.addUnique(rotated)
+		}
+	}
+	return extended
+}
+
+// WithReflections returns new catalogue extended with mirrored versions of
+// its shapes which are not already in the catalogue
+func (catalogue *Catalogue) WithReflections() *Catalogue {
+	extended := NewCatalogue(catalogue.Name, catalogue.Shapes...)
+	for _, shape := range catalogue.Shapes {
+		reflected := shape.Reflected()
+		reflected.Name = shape.Name + " mirrored"
+		extended.addUnique(reflected)
+	}
+	return extended
+}
+
+func (catalogue *Catalogue) add(shape Shape) {
+	shape.ID = len(catalogue.Shapes)
+	shape = shape.normalized()
+	catalogue.Shapes = append(catalogue.Shapes, shape)
+
+	grid := shape.Grid()
+	if key, ok := gridKey(grid); ok {
+		if catalogue.offsets == nil {
+			catalogue.offsets = make(map[uint32]blockOffsets)
+		}
+		catalogue.offsets[key] = offsetsOf(grid)
+	}
+}
+
+// offsetsOf returns offsets of filled cells of the block, offsets of the
+// shapes are precomputed and only other blocks are converted
+func (catalogue *Catalogue) offsetsOf(block [][]BoardElement) blockOffsets {
+	if key, ok := gridKey(block); ok {
+		if offsets, ok := catalogue.offsets[key]; ok {
+			return offsets
+		}
+	}
+	return offsetsOf(block)
+}
+
+func (catalogue *Catalogue) addUnique(shape Shape) {
+	for _, existing := range catalogue.Shapes {
+		if existing.SameCells(shape) {
+			return
+		}
+	}
+	catalogue.add(shape)
+}
+
+// grid returns container with the shape of given ID, empty container is
+// returned for unknown ID
+func (catalogue *Catalogue) grid(id int) [][]BoardElement {
+	shape, ok := catalogue.Shape(id)
+	if !ok {
+		return createContainer(blockSize)
+	}
+	return shape.Grid()
+}
+
+// catalogueFile is the format of the file loaded by LoadCatalogue. Shapes
+// can be described either by rows of text or by list of cells.
+type catalogueFile struct {
+	Name        string      `json:"name" yaml:"name"`
+	Rotations   bool        `json:"rotations" yaml:"rotations"`
+	Reflections bool        `json:"reflections" yaml:"reflections"`
+	Shapes      []shapeFile `json:"shapes" yaml:"shapes"`
+}
+
+type shapeFile struct {
+	Name  string       `json:"name" yaml:"name"`
+	Color BoardElement `json:"color" yaml:"color"`
+	Rows  []string     `json:"rows" yaml:"rows"`
+	Cells []Cell       `json:"cells" yaml:"cells"`
+}
+
+// LoadCatalogue reads catalogue from JSON or YAML file, format is chosen
+// by the file extension (.json, .yaml or .yml)
+func LoadCatalogue(name string) (*Catalogue, error) {
+	data, err := ioutil.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".json":
+		return ParseCatalogueJSON(data)
+	case ".yaml", ".yml":
+		return ParseCatalogueYAML(data)
+	}
+	return nil, fmt.Errorf("Unsupported catalogue file format %q", filepath.Ext(name))
+}
+
+// ParseCatalogueJSON parses catalogue described in JSON format, eg.
+//
+//	{"name": "tetrominoes", "rotations": true, "shapes": [
+//	    {"name": "T", "color": "magenta", "rows": ["###", ".#."]}]}
+func ParseCatalogueJSON(data []byte) (*Catalogue, error) {
+	var file catalogueFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, err
+	}
+	return file.catalogue()
+}
+
+// ParseCatalogueYAML parses catalogue described in YAML format using the same
+// fields as ParseCatalogueJSON
+func ParseCatalogueYAML(data []byte) (*Catalogue, error) {
+	var file catalogueFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, err
+	}
+	return file.catalogue()
+}
+
+func (file catalogueFile) catalogue() (*Catalogue, error) {
+	if len(file.Shapes) == 0 {
+		return nil, fmt.Errorf("Catalogue %q has no shapes", file.Name)
+	}
+
+	catalogue := NewCatalogue(file.Name)
+	for _, description := range file.Shapes {
+		shape := NewShape(description.Name, description.Color, description.Rows...)
+		if len(description.Cells) > 0 {
+			shape = Shape{Name: description.Name, Color: description.Color, Cells: description.Cells}
+		}
+		if err := shape.validate(); err != nil {
+			return nil, err
+		}
+		catalogue.addUnique(shape)
+	}
+
+	if file.Rotations {
+		catalogue = catalogue.WithRotations()
+	}
+	if file.Reflections {
+		catalogue = catalogue.WithReflections()
+	}
+	return catalogue, nil
+}
@@ -0,0 +1,88 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDefaultCatalogue(t *testing.T) {
+	assert := assert.New(t)
+
+	catalogue := DefaultCatalogue()
+
+	assert.Equal(ShapesCount, catalogue.Len())
+	for id, shape := range catalogue.Shapes {
+		assert.Equal(id, shape.ID)
+	}
+	assert.Equal(9, catalogue.Find(blockShape(9)))
+	assert.Equal(-1, catalogue.Find(NewShape("X", Red, ".#.", "###", ".#.").Grid()))
+
+	_, ok := catalogue.Shape(ShapesCount)
+	assert.False(ok)
+
+	assert.Equal(5, catalogue.GridSize())
+	assert.Equal(7, NewCatalogue("tall", NewShape("seven", Red, "#", "#", "#", "#", "#", "#", "#")).GridSize())
+}
+
+func TestCatalogueWithRotations(t *testing.T) {
+	assert := assert.New(t)
+
+	// all rotations of default shapes are already in the catalogue
+	assert.Equal(ShapesCount, DefaultCatalogue().WithRotations().Len())
+	assert.Equal(ShapesCount, DefaultCatalogue().WithReflections().Len())
+
+	catalogue := NewCatalogue("tetrominoes", NewShape("T", Magenta, "###", ".#."), NewShape("S", Green, ".##", "##."))
+
+	assert.Equal(6, catalogue.WithRotations().Len())
+	assert.Equal(3, catalogue.WithReflections().Len())
+	assert.Equal(8, catalogue.WithRotations().WithReflections().Len())
+}
+
+func TestParseCatalogueJSON(t *testing.T) {
+	assert := assert.New(t)
+
+	catalogue, err := ParseCatalogueJSON([]byte(`{"name": "pack", "shapes": [
+		{"name": "T", "color": "magenta", "rows": ["###", ".#."]},
+		{"name": "dot", "color": "red", "cells": [{"x": 0, "y": 0}]}]}`))
+
+	assert.Nil(err)
+	assert.Equal("pack", catalogue.Name)
+	assert.Equal(2, catalogue.Len())
+	assert.Equal(Magenta, catalogue.Shapes[0].Color)
+	assert.Equal("###/.#.", catalogue.Shapes[0].String())
+	assert.Equal(1, catalogue.Shapes[1].ID)
+
+	_, err = ParseCatalogueJSON([]byte(`{"shapes": [{"name": "T", "color": "pink", "rows": ["###"]}]}`))
+	assert.NotNil(err)
+	_, err = ParseCatalogueJSON([]byte(`{"shapes": [{"name": "empty", "color": "red"}]}`))
+	assert.NotNil(err)
+	_, err = ParseCatalogueJSON([]byte(`{"shapes": []}`))
+	assert.NotNil(err)
+}
+
+func TestLoadCatalogue(t *testing.T) {
+	assert := assert.New(t)
+
+	catalogue, err := LoadCatalogue("testdata/pentominoes.yaml")
+
+	assert.Nil(err)
+	assert.Equal("pentominoes", catalogue.Name)
+	// 12 free pentominoes have 63 fixed orientations
+	assert.Equal(63, catalogue.Len())
+
+	_, err = LoadCatalogue("testdata/missing.json")
+	assert.NotNil(err)
+}
+
+func TestGameWithCatalogue(t *testing.T) {
+	assert := assert.New(t)
+
+	catalogue := NewCatalogue("plus", NewShape("X", Yellow, ".#.", "###", ".#."))
+	g := NewWithOptions(WithCatalogue(catalogue))
+
+	assert.Equal(catalogue, g.Shapes())
+	assert.Equal(catalogue.Shapes[0].Grid(), g.Blocks[A])
+	assert.Nil(g.Move(A, 0, 0))
+	assert.Equal(5, g.Score)
+}
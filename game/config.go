@@ -0,0 +1,101 @@
+package game
+
+import (
+	"time"
+)
+
+// InvalidMovePolicy decides what happens when the move is not possible
+type InvalidMovePolicy int
+
+// InvalidMovePolicy can be one of the following values
+const (
+	// EndGame puts the game in a game over state
+	EndGame InvalidMovePolicy = 0
+	// IgnoreInvalidMove returns error and lets the player continue
+	IgnoreInvalidMove InvalidMovePolicy = 1
+	// PenalizeInvalidMove returns error, subtracts InvalidMovePenalty from
+	// the score and lets the player continue
+	PenalizeInvalidMove InvalidMovePolicy = 2
+)
+
+// Config describes rules of the game. Zero values of the fields select rules
+// of the original game or no limit, so that only changed rules have to be
+// set, eg. Config{Rows: 8, Columns: 8} is the original game on a smaller
+// board. Config returned by DefaultConfig is a base for changes as well.
+type Config struct {
+	// Rows and Columns set size of the board
+	Rows    int `json:"rows"`
+	Columns int `json:"columns"`
+	// Blocks is the number of blocks offered to the player at once
+	Blocks int `json:"blocks"`
+	// KeepRows and KeepColumns disable removal of full rows or columns
+	KeepRows    bool `json:"keepRows"`
+	KeepColumns bool `json:"keepColumns"`
+	// InvalidMove decides what happens after a move which is not possible,
+	// InvalidMovePenalty is used with PenalizeInvalidMove policy
+	InvalidMove        InvalidMovePolicy `json:"invalidMove"`
+	InvalidMovePenalty int               `json:"invalidMovePenalty"`
+	// Engine decides how moves are computed, it does not change the rules
+	Engine Engine `json:"engine"`
+	// Scoring selects rules deciding how many points are received for a move,
+	// other rules can be set with WithScoring option
+	Scoring ScoringMode `json:"scoring"`
+	// Hold enables the hold slot where one of the offered blocks can be
+	// parked for later
+	Hold bool `json:"hold"`
+	// Rerolls is the number of times in a game all offered blocks can be
+	// replaced with new ones
+	Rerolls int `json:"rerolls"`
+	// Rotation allows to rotate offered blocks when the rotated shape is in
+	// the catalogue
+	Rotation bool `json:"rotation"`
+	// MoveLimit ends the game after a given number of placed blocks and
+	// TimeLimit after a given time measured by the clock of the game, zero
+	// means no limit
+	MoveLimit int           `json:"moveLimit"`
+	TimeLimit time.Duration `json:"timeLimit"`
+}
+
+// DefaultConfig returns rules of the original game: 10x10 board, three
+// blocks and removal of both full rows and columns. Invalid move ends the game.
+func DefaultConfig() Config {
+	return Config{
+		Rows:    10,
+		Columns: 10,
+		Blocks:  3,
+	}
+}
+
+// normalized returns copy of the config with values which do not make sense
+// replaced by the default ones
+func (config Config) normalized() Config {
+	defaultConfig := DefaultConfig()
+	if config.Rows <= 0 {
+		config.Rows = defaultConfig.Rows
+	}
+	if config.Columns <= 0 {
+		config.Columns = defaultConfig.Columns
+	}
+	if config.Blocks <= 0 {
+		config.Blocks = defaultConfig.Blocks
+	}
+	if config.InvalidMovePenalty < 0 {
+		config.InvalidMovePenalty = 0
+	}
+	if config.Rerolls < 0 {
+		config.Rerolls = 0
+	}
+	if config.MoveLimit < 0 {
+		config.MoveLimit = 0
+	}
+	if config.TimeLimit < 0 {
+		config.TimeLimit = 0
+	}
+	if config.Engine == BitboardEngine && config.Rows*config.Columns > bitboardCells {
+		config.Engine = SliceEngine
+	}
+	if config.Scoring != ClassicScoring && config.Scoring != ComboScoring {
+		config.Scoring = ClassicScoring
+	}
+	return config
+}
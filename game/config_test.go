@@ -0,0 +1,108 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigNormalized(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(DefaultConfig(), Config{}.normalized())
+	assert.Equal(Config{Rows: 8, Columns: 12, Blocks: 2}, Config{Rows: 8, Columns: 12, Blocks: 2}.normalized())
+
+	// zero config clears full lanes in the same way as the default one
+	g := NewWithOptions(WithConfig(Config{Rows: 8, Columns: 8}))
+	g.Blocks[A] = blockShape(0)
+	for y := 0; y < 7; y++ {
+		g.Board[0][y] = Red
+	}
+	assert.Nil(g.Move(A, 0, 7))
+	assert.Equal(createBoard(8, 8), g.Board)
+}
+
+func TestRectangularBoard(t *testing.T) {
+	assert := assert.New(t)
+
+	config := DefaultConfig()
+	config.Rows = 12
+	config.Columns = 9
+	config.Blocks = 4
+	g := NewWithOptions(WithConfig(config), WithGenerator(NewScriptedGenerator(7, 8)))
+
+	assert.Equal(config, g.Config())
+	assert.Equal(12, len(g.Board))
+	assert.Equal(9, len(g.Board[0]))
+	assert.Equal(4, len(g.Blocks))
+
+	// five horizontal does not fit in 9 columns when starting at column 5
+	assert.False(g.isMovePossible(blockShape(7), 0, 5))
+	assert.True(g.isMovePossible(blockShape(7), 11, 4))
+	assert.True(g.isMovePossible(blockShape(8), 7, 8))
+	assert.False(g.isMovePossible(blockShape(8), 8, 8))
+
+	assert.NotNil(g.Move(4, 0, 0))
+}
+
+func TestClearOnlyRowsOrColumns(t *testing.T) {
+	assert := assert.New(t)
+
+	config := DefaultConfig()
+	config.KeepColumns = true
+	g := NewWithOptions(WithConfig(config))
+
+	for i := 0; i < 10; i++ {
+		g.Board[i][0] = Green
+		g.Board[0][i] = Green
+	}
+
+	rows, columns := g.checkAndRemoveFullLanes(g.Board)
+	assert.Equal([]int{0}, rows)
+	assert.Empty(columns)
+	assert.Equal(None, g.Board[0][5])
+	assert.Equal(None, g.Board[0][0])
+	assert.Equal(Green, g.Board[5][0])
+
+	config = DefaultConfig()
+	config.KeepRows = true
+	g = NewWithOptions(WithConfig(config))
+
+	for i := 0; i < 10; i++ {
+		g.Board[0][i] = Green
+	}
+
+	rows, columns = g.checkAndRemoveFullLanes(g.Board)
+	assert.Empty(rows)
+	assert.Empty(columns)
+	assert.Equal(Green, g.Board[0][5])
+}
+
+func TestInvalidMovePolicy(t *testing.T) {
+	assert := assert.New(t)
+
+	config := DefaultConfig()
+	g := NewWithOptions(WithConfig(config))
+	err := g.Move(A, -1, 0)
+	assert.True(IsInvalidMove(err))
+	assert.True(g.GameOver)
+
+	config.InvalidMove = IgnoreInvalidMove
+	g = NewWithOptions(WithConfig(config))
+	err = g.Move(7, 0, 0)
+	assert.Equal(IncorrectBlock, err.(*ErrorGame).Reason)
+	assert.False(g.GameOver)
+	assert.Equal(0, g.Score)
+	assert.Nil(g.Move(A, 0, 0))
+
+	config.InvalidMove = PenalizeInvalidMove
+	config.InvalidMovePenalty = 3
+	g = NewWithOptions(WithConfig(config))
+	err = g.Move(A, 10, 10)
+	assert.Equal(IncorrectPosition, err.(*ErrorGame).Reason)
+	assert.False(g.GameOver)
+	assert.Equal(-3, g.Score)
+
+	assert.False(IsInvalidMove(nil))
+	assert.False(IsInvalidMove(&ErrorGame{GameOver, ""}))
+}
@@ -3,12 +3,14 @@ package game
 import (
 	"fmt"
 	"math/rand"
+	"strings"
+	"time"
 )
 
 // BoardElement represents single object on the game board
 type BoardElement int
 
-// BoardElement can be one of the following colors
+// BoardElement can be one of the following colors or a stone
 const (
 	None    BoardElement = 0
 	Red     BoardElement = 1
@@ -18,12 +20,45 @@ const (
 	Magenta BoardElement = 5
 	Cyan    BoardElement = 6
 	White   BoardElement = 7
+	// Stone is an immovable obstacle, it fills the cell but is never removed
+	// with the lane it belongs to
+	Stone BoardElement = 8
 )
 
-// BlockType represents one of the three blocks available in the game
+var boardElementNames = []string{"none", "red", "green", "yellow", "blue", "magenta", "cyan", "white", "stone"}
+
+// String returns lowercase name of the color
+func (element BoardElement) String() string {
+	if element < 0 || int(element) >= len(boardElementNames) {
+		return fmt.Sprintf("BoardElement(%d)", int(element))
+	}
+	return boardElementNames[element]
+}
+
+// MarshalText encodes BoardElement as its name
+func (element BoardElement) MarshalText() ([]byte, error) {
+	if element < 0 || int(element) >= len(boardElementNames) {
+		return nil, fmt.Errorf("Unknown board element %d", int(element))
+	}
+	return []byte(element.String()), nil
+}
+
+// UnmarshalText decodes BoardElement from its name
+func (element *BoardElement) UnmarshalText(text []byte) error {
+	for value, name := range boardElementNames {
+		if name == strings.ToLower(string(text)) {
+			*element = BoardElement(value)
+			return nil
+		}
+	}
+	return fmt.Errorf("Unknown board element %q", string(text))
+}
+
+// BlockType represents one of the blocks available in the game, it is an
+// index in Game.Blocks
 type BlockType int
 
-// BlockType can be one of the following values
+// BlockType of the three blocks available in the default configuration
 const (
 	A BlockType = 0
 	B BlockType = 1
@@ -38,6 +73,8 @@ const (
 	GameOver          ErrorGameReason = 1
 	IncorrectBlock    ErrorGameReason = 2
 	IncorrectPosition ErrorGameReason = 3
+	NoHistory         ErrorGameReason = 4
+	IncorrectAction   ErrorGameReason = 5
 )
 
 // ErrorGame struct implements Error interface and is used to communicate
@@ -51,94 +88,292 @@ func (e *ErrorGame) Error() string {
 	return e.Message
 }
 
-// Game struct contains 10x10 game board and three 5x5 blocks of shapes
+// IsInvalidMove checks if the error was caused by incorrect block, position
+// or action
+func IsInvalidMove(err error) bool {
+	errorGame, ok := err.(*ErrorGame)
+	if !ok {
+		return false
+	}
+	return errorGame.Reason == IncorrectBlock || errorGame.Reason == IncorrectPosition || errorGame.Reason == IncorrectAction
+}
+
+// Game struct contains game board (10x10 by default) and blocks of shapes
+// offered to the player (three 5x5 blocks by default)
 type Game struct {
-	Board    [][]BoardElement
-	BlockA   [][]BoardElement
-	BlockB   [][]BoardElement
-	BlockC   [][]BoardElement
+	Board  [][]BoardElement
+	Blocks [][][]BoardElement
+	// Held is the block in the hold slot, nil when the slot is not enabled
+	Held     [][]BoardElement
 	Score    int
 	GameOver bool
 
+	streak          int
+	rerolls         int
+	actions         int
+	config          Config
+	seed            int64
+	random          *randomSource
 	randomGenerator *rand.Rand
+	generator       BlockGenerator
+	scoring         ScoringRules
+	shapes          *Catalogue
+	history         *history
+	masks           *boardMasks
+	bits            bitboardState
+	placeable       *placeableCache
+	// placeableVersion is the version of the cache known to the game
+	placeableVersion int
+	observers        []Observer
+	moves            int
+	clock            Clock
+	started          time.Time
 }
 
+// DefaultSeed is used by New to seed the pseudo random generator
+const DefaultSeed int64 = 1
+
 // New Game is used to initialize Game struct: 10x10 board and three randomly
-// assigned blocks
+// assigned blocks. Blocks are drawn from generator seeded with DefaultSeed.
 func New() Game {
-	var g Game
-	// randomSource := rand.NewSource(time.Now().UnixNano())
-	randomSource := rand.NewSource(1)
-	g.randomGenerator = rand.New(randomSource)
-	g.Board = createContainer(10)
+	return NewWithOptions()
+}
+
+// NewWithSeed initializes Game the same way as New but seeds the pseudo
+// random generator with provided seed
+func NewWithSeed(seed int64) Game {
+	return NewWithOptions(WithSeed(seed))
+}
+
+// NewWithOptions initializes Game applying all provided options on top of
+// the default settings used by New. It panics when ScriptedGenerator deals
+// shapes which are not in the catalogue.
+func NewWithOptions(options ...Option) Game {
+	settings := defaultSettings()
+	for _, option := range options {
+		option(&settings)
+	}
+	if err := settings.validate(); err != nil {
+		panic(err.Error())
+	}
+
+	g := newGame(settings)
 	g.assignRandomBlocks()
+	if settings.board != nil {
+		g.GameOver = g.isGameOver()
+	}
+	return g
+}
+
+// newGame returns Game with empty board and blocks configured by settings
+func newGame(settings settings) Game {
+	var g Game
+	g.seed = settings.seed
+	g.random = newRandomSource(settings.seed)
+	g.randomGenerator = rand.New(g.random)
+	g.generator = settings.generator
+	g.scoring = settings.scoring
+	g.shapes = settings.shapes
+	if len(settings.board) > 0 && len(settings.board[0]) > 0 {
+		settings.config.Rows = len(settings.board)
+		settings.config.Columns = len(settings.board[0])
+	}
+	g.config = settings.config.normalized()
+	g.Board = createBoard(g.config.Rows, g.config.Columns)
+	for x := range settings.board {
+		copy(g.Board[x], settings.board[x])
+	}
+	if g.config.Engine == BitboardEngine {
+		g.masks = masksForBoard(g.config.Rows, g.config.Columns, g.shapes)
+	}
+	g.Blocks = make([][][]BoardElement, g.config.Blocks)
+	for i := range g.Blocks {
+		g.Blocks[i] = createContainer(blockSize)
+	}
+	if g.config.Hold {
+		g.Held = createContainer(blockSize)
+	}
+	g.rerolls = g.config.Rerolls
+	if settings.history {
+		g.history = &history{}
+	}
+	g.observers = settings.observers
+	g.clock = settings.clock
+	g.started = g.clock.Now()
 	return g
 }
 
+// Config returns configuration of the game
+func (g Game) Config() Config {
+	return g.config
+}
+
+// Seed returns the seed used to initialize the pseudo random generator
+func (g Game) Seed() int64 {
+	return g.seed
+}
+
 // Move is used to select one of available blocks and place it on x,y position.
-// In case the placement is not possible or block does not exist error is returned.
+// In case the placement is not possible or block does not exist error is returned
+// and the game continues according to the InvalidMovePolicy of the config.
 func (g *Game) Move(block BlockType, x int, y int) error {
+	return g.Play(Move{block, x, y, PlaceBlock})
+}
+
+// Play makes the move: placement of the block or one of the actions enabled
+// by the config. Invalid move is handled in the same way as by Move. Game
+// ends when the move or time limit of the config is reached.
+func (g *Game) Play(move Move) error {
 	if g.GameOver {
 		return &ErrorGame{GameOver, "Cannot continue playing game in a game over state"}
 	}
-
-	var selectedBlock [][]BoardElement
-	switch block {
-	case A:
-		selectedBlock = g.BlockA
-	case B:
-		selectedBlock = g.BlockB
-	case C:
-		selectedBlock = g.BlockC
-	default:
-		g.GameOver = true
-		return &ErrorGame{IncorrectBlock, fmt.Sprintf("Incorrect block type specified (%d)", block)}
-	}
-
-	if isBlockEmpty(selectedBlock) {
+	if reason, ok := g.limitReached(); ok {
 		g.GameOver = true
-		return &ErrorGame{IncorrectBlock, "Selected block is empty"}
+		g.notify(Event{Type: GameOverEvent, Move: move, Score: g.Score})
+		return &ErrorGame{GameOver, reason}
 	}
 
-	error := g.placeBlock(x, y, selectedBlock)
+	result, error := g.play(move)
 	if error != nil {
-		g.GameOver = true
+		switch g.config.InvalidMove {
+		case IgnoreInvalidMove:
+		case PenalizeInvalidMove:
+			g.Score -= g.config.InvalidMovePenalty
+			if g.config.InvalidMovePenalty != 0 {
+				g.notify(Event{Type: ScoreChangedEvent, Move: move, Score: g.Score, ScoreDelta: -g.config.InvalidMovePenalty})
+			}
+		default:
+			g.GameOver = true
+			g.notify(Event{Type: GameOverEvent, Move: move, Score: g.Score})
+		}
 		return error
 	}
 
-	emptyBlock := createContainer(5)
-	switch block {
-	case A:
-		g.BlockA = emptyBlock
-	case B:
-		g.BlockB = emptyBlock
-	case C:
-		g.BlockC = emptyBlock
+	if result.GameOver {
+		if reason, ok := g.limitReached(); ok {
+			return &ErrorGame{GameOver, reason}
+		}
+		return &ErrorGame{GameOver, "No other move is possible. Game over."}
+	}
+
+	return nil
+}
+
+// play makes the move, deals new blocks when all blocks were used and checks
+// if the game is over. In case the move is not possible error is returned
+// and the game is not modified.
+func (g *Game) play(move Move) (MoveResult, error) {
+	return g.makeMove(move, true)
+}
+
+// makeMove does the same as play, new blocks are dealt only when deal is
+// true. Without the deal blocks stay empty after the last one is used and
+// the game over is not checked, as it depends on the next blocks.
+func (g *Game) makeMove(move Move, deal bool) (MoveResult, error) {
+	result := MoveResult{Move: move}
+
+	var state Game
+	if g.history != nil {
+		state = g.cloneState()
+	}
+
+	scoreBefore := g.Score
+	var err error
+	switch move.Action {
+	case PlaceBlock:
+		result, err = g.placeSelectedBlock(move.Block, move.X, move.Y)
+	case HoldBlock:
+		err = g.holdBlock(move.Block)
+	case RotateBlock:
+		err = g.rotateBlock(move.Block)
+	case Reroll:
+		if !deal {
+			err = &ErrorGame{IncorrectAction, "Reroll cannot be made without dealing new blocks"}
+			break
+		}
+		err = g.reroll()
+		result.BlocksDealt = true
+	default:
+		err = &ErrorGame{IncorrectAction, fmt.Sprintf("Incorrect action specified (%d)", move.Action)}
+	}
+	if err != nil {
+		return MoveResult{Move: move}, err
+	}
+	result.Move = move
+
+	if g.history != nil {
+		g.record(state, move)
 	}
 
-	if isBlockEmpty(g.BlockA) && isBlockEmpty(g.BlockB) && isBlockEmpty(g.BlockC) {
-		g.assignRandomBlocks()
+	if g.allBlocksEmpty() {
+		if deal {
+			g.assignRandomBlocks()
+		}
+		result.BlocksDealt = true
 	}
 
-	if g.isGameOver() {
+	result.ScoreDelta = g.Score - scoreBefore
+
+	if _, ok := g.limitReached(); ok || ((deal || !result.BlocksDealt) && g.isGameOver()) {
 		g.GameOver = true
-		return &ErrorGame{GameOver, "No other move is possible. Game over."}
+		result.GameOver = true
 	}
 
-	return nil
+	g.notifyMove(result)
+	return result, nil
 }
 
+// placeSelectedBlock places the block on the board, adds points for the move,
+// counts the move and empties the block
+func (g *Game) placeSelectedBlock(block BlockType, x int, y int) (MoveResult, error) {
+	selectedBlock, err := g.selectedBlock(block)
+	if err != nil {
+		return MoveResult{}, err
+	}
+
+	var result MoveResult
+	if g.masks != nil {
+		result, err = g.placeBlockBitboard(x, y, selectedBlock)
+	} else {
+		result, err = g.placeBlock(x, y, selectedBlock)
+	}
+	if err != nil {
+		return result, err
+	}
+	g.score(&result)
+	g.moves++
+	g.actions = 0
+
+	g.setBlock(block, createContainer(len(selectedBlock)))
+	return result, nil
+}
+
+// assignRandomBlocks deals new blocks, slice of blocks is replaced so that
+// value copies of the game keep their blocks
 func (g *Game) assignRandomBlocks() {
-	g.BlockA = g.randomShape()
-	g.BlockB = g.randomShape()
-	g.BlockC = g.randomShape()
+	blocks := make([][][]BoardElement, len(g.Blocks))
+	for i := range blocks {
+		blocks[i] = g.randomShape()
+	}
+	g.Blocks = blocks
+}
+
+func (g *Game) allBlocksEmpty() bool {
+	for _, block := range g.Blocks {
+		if !isBlockEmpty(block) {
+			return false
+		}
+	}
+	return true
 }
 
 // placeBlock places provided block on the board at x and y position being 0,0 block's position.
-// In case the placement is not possible error is returned.
-func (g *Game) placeBlock(x int, y int, block [][]BoardElement) error {
+// In case the placement is not possible error is returned. Returned result describes placed
+// cells and removed lanes, the score is not changed.
+func (g *Game) placeBlock(x int, y int, block [][]BoardElement) (MoveResult, error) {
+	var result MoveResult
 	if x < 0 || y < 0 {
-		return &ErrorGame{IncorrectPosition, fmt.Sprintf("%d,%d is below 0,0", x, y)}
+		return result, &ErrorGame{IncorrectPosition, fmt.Sprintf("%d,%d is below 0,0", x, y)}
 	}
 
 	newBoard := make([][]BoardElement, len(g.Board))
@@ -153,9 +388,9 @@ func (g *Game) placeBlock(x int, y int, block [][]BoardElement) error {
 		for boardY := y; boardY < y+len(block); boardY++ {
 			blockX := boardX - x
 			blockY := boardY - y
-			if boardX >= len(newBoard) || boardY >= len(newBoard) {
+			if boardX >= len(newBoard) || boardY >= len(newBoard[0]) {
 				if block[blockX][blockY] != None {
-					return &ErrorGame{IncorrectPosition, fmt.Sprintf("%d,%d is out of board and block at %d,%d is not empty (%d)", boardX, boardY, blockX, blockY, block[blockX][blockY])}
+					return result, &ErrorGame{IncorrectPosition, fmt.Sprintf("%d,%d is out of board and block at %d,%d is not empty (%d)", boardX, boardY, blockX, blockY, block[blockX][blockY])}
 				}
 				continue
 			}
@@ -165,16 +400,19 @@ func (g *Game) placeBlock(x int, y int, block [][]BoardElement) error {
 					placementScore++
 				}
 			} else if block[blockX][blockY] != None {
-				return &ErrorGame{IncorrectPosition, fmt.Sprintf("board at %d,%d is not empty (%d) and block at %d,%d is also not empty (%d)", boardX, boardY, newBoard[boardX][boardY], blockX, blockY, block[blockX][blockY])}
+				return result, &ErrorGame{IncorrectPosition, fmt.Sprintf("board at %d,%d is not empty (%d) and block at %d,%d is also not empty (%d)", boardX, boardY, newBoard[boardX][boardY], blockX, blockY, block[blockX][blockY])}
 			}
 		}
 	}
 
-	fullLanesScore := checkAndRemoveFullLanes(newBoard)
+	rows, columns := g.checkAndRemoveFullLanes(newBoard)
 
 	g.Board = newBoard
-	g.Score += placementScore + fullLanesScore
-	return nil
+
+	result.CellsPlaced = placementScore
+	result.RowsCleared = rows
+	result.ColumnsCleared = columns
+	return result, nil
 }
 
 func (g *Game) isMovePossible(block [][]BoardElement, x int, y int) bool {
@@ -182,7 +420,7 @@ func (g *Game) isMovePossible(block [][]BoardElement, x int, y int) bool {
 		for boardY := y; boardY < y+len(block); boardY++ {
 			blockX := boardX - x
 			blockY := boardY - y
-			if boardX >= len(g.Board) || boardY >= len(g.Board) {
+			if boardX >= len(g.Board) || boardY >= len(g.Board[0]) {
 				if block[blockX][blockY] != None {
 					return false
 				}
@@ -198,246 +436,115 @@ func (g *Game) isMovePossible(block [][]BoardElement, x int, y int) bool {
 }
 
 // checkAndRemoveFullLanes firstly counts all full rows and columns
-// and then removes them from the board, replacing with None value
-func checkAndRemoveFullLanes(board [][]BoardElement) int {
+// and then removes them from the board, replacing with None value.
+// Only lanes enabled in the game configuration are removed. Stones fill
+// the lane but are not removed, lane made only of stones is never full.
+// Returns indexes of removed rows and columns.
+func (g *Game) checkAndRemoveFullLanes(board [][]BoardElement) (rows []int, columns []int) {
 	fullRows := make([]bool, len(board))
-	fullCols := make([]bool, len(board))
-	for i := 0; i < len(board); i++ {
-		fullRows[i] = true
-		fullCols[i] = true
+	fullCols := make([]bool, len(board[0]))
+	for i := range fullRows {
+		fullRows[i] = !g.config.KeepRows
+	}
+	for i := range fullCols {
+		fullCols[i] = !g.config.KeepColumns
 	}
 
-	score := 0
+	stoneRows := make([]bool, len(board))
+	stoneCols := make([]bool, len(board[0]))
+	for i := range stoneRows {
+		stoneRows[i] = true
+	}
+	for i := range stoneCols {
+		stoneCols[i] = true
+	}
 
 	// check all full rows and columns before removing anything
-	for x := 0; x < len(board); x++ {
-		for y := 0; y < len(board); y++ {
+	for x := 0; x < len(fullRows); x++ {
+		for y := 0; y < len(fullCols); y++ {
 			if fullRows[x] {
 				fullRows[x] = board[x][y] != None
 			}
 			if fullCols[y] {
 				fullCols[y] = board[x][y] != None
 			}
+			if board[x][y] != Stone {
+				stoneRows[x] = false
+				stoneCols[y] = false
+			}
 		}
 	}
 
 	// remove rows
 	for x := 0; x < len(fullRows); x++ {
-		if !fullRows[x] {
+		if !fullRows[x] || stoneRows[x] {
 			continue
 		}
 
-		score += len(fullCols)
+		rows = append(rows, x)
 
 		for y := 0; y < len(fullCols); y++ {
-			board[x][y] = None
+			if board[x][y] != Stone {
+				board[x][y] = None
+			}
 		}
 	}
 
 	// remove columns
 	for y := 0; y < len(fullCols); y++ {
-		if !fullCols[y] {
+		if !fullCols[y] || stoneCols[y] {
 			continue
 		}
 
-		score += len(fullRows)
+		columns = append(columns, y)
 
 		for x := 0; x < len(fullRows); x++ {
-			board[x][y] = None
+			if board[x][y] != Stone {
+				board[x][y] = None
+			}
 		}
 	}
 
-	return score
+	return rows, columns
 }
 
+// isGameOver checks if any block, including the held one and rotations, can
+// be placed on the board or new blocks can be dealt without placing a block.
+// Positions where blocks fit are cached between moves, so that only the area
+// changed by the last move is checked again.
 func (g *Game) isGameOver() bool {
-	movePossible := false
-	if !isBlockEmpty(g.BlockA) && g.isMovePossibleAnywhere(g.BlockA) {
-		movePossible = true
-	}
-	if !isBlockEmpty(g.BlockB) && g.isMovePossibleAnywhere(g.BlockB) {
-		movePossible = true
-	}
-	if !isBlockEmpty(g.BlockC) && g.isMovePossibleAnywhere(g.BlockC) {
-		movePossible = true
+	if g.updatePlaceable(g.playableBlocks()).any() {
+		return false
 	}
 
-	return !movePossible
+	return !g.canChangeBlocks()
 }
 
-func (g *Game) isMovePossibleAnywhere(block [][]BoardElement) bool {
-	for x := 0; x < len(g.Board); x++ {
-		for y := 0; y < len(g.Board[x]); y++ {
-			if g.Board[x][y] != None {
-				continue
-			}
-
-			if g.isMovePossible(block, x, y) {
-				return true
-			}
-		}
-	}
-
-	return false
+// randomShape returns shape from the catalogue chosen by the generator
+func (g *Game) randomShape() [][]BoardElement {
+	return g.shapes.grid(g.generator.NextShape(g.randomGenerator, g.shapes.Len()))
 }
 
-// randomShape returns random shape from BlockShape method
-func (g *Game) randomShape() [][]BoardElement {
-	return blockShape(g.randomGenerator.Intn(19))
+// Shapes returns catalogue of shapes which are dealt in the game
+func (g Game) Shapes() *Catalogue {
+	return g.shapes
 }
 
-// blockShape returns one of 19 shapes available in the game
+// blockShape returns one of ShapesCount shapes of the default catalogue
 func blockShape(number int) [][]BoardElement {
-	switch number {
-	case 0:
-		return [][]BoardElement{
-			{Red, None, None, None, None},
-			{None, None, None, None, None},
-			{None, None, None, None, None},
-			{None, None, None, None, None},
-			{None, None, None, None, None}}
-	case 1:
-		return [][]BoardElement{
-			{Green, Green, None, None, None},
-			{None, None, None, None, None},
-			{None, None, None, None, None},
-			{None, None, None, None, None},
-			{None, None, None, None, None}}
-	case 2:
-		return [][]BoardElement{
-			{Green, None, None, None, None},
-			{Green, None, None, None, None},
-			{None, None, None, None, None},
-			{None, None, None, None, None},
-			{None, None, None, None, None}}
-	case 3:
-		return [][]BoardElement{
-			{Yellow, Yellow, Yellow, None, None},
-			{None, None, None, None, None},
-			{None, None, None, None, None},
-			{None, None, None, None, None},
-			{None, None, None, None, None}}
-	case 4:
-		return [][]BoardElement{
-			{Yellow, None, None, None, None},
-			{Yellow, None, None, None, None},
-			{Yellow, None, None, None, None},
-			{None, None, None, None, None},
-			{None, None, None, None, None}}
-	case 5:
-		return [][]BoardElement{
-			{Blue, Blue, Blue, Blue, None},
-			{None, None, None, None, None},
-			{None, None, None, None, None},
-			{None, None, None, None, None},
-			{None, None, None, None, None}}
-	case 6:
-		return [][]BoardElement{
-			{Blue, None, None, None, None},
-			{Blue, None, None, None, None},
-			{Blue, None, None, None, None},
-			{Blue, None, None, None, None},
-			{None, None, None, None, None}}
-	case 7:
-		return [][]BoardElement{
-			{Magenta, Magenta, Magenta, Magenta, Magenta},
-			{None, None, None, None, None},
-			{None, None, None, None, None},
-			{None, None, None, None, None},
-			{None, None, None, None, None}}
-	case 8:
-		return [][]BoardElement{
-			{Magenta, None, None, None, None},
-			{Magenta, None, None, None, None},
-			{Magenta, None, None, None, None},
-			{Magenta, None, None, None, None},
-			{Magenta, None, None, None, None}}
-	case 9:
-		return [][]BoardElement{
-			{Cyan, Cyan, None, None, None},
-			{Cyan, Cyan, None, None, None},
-			{None, None, None, None, None},
-			{None, None, None, None, None},
-			{None, None, None, None, None}}
-	case 10:
-		return [][]BoardElement{
-			{White, White, White, None, None},
-			{White, White, White, None, None},
-			{White, White, White, None, None},
-			{None, None, None, None, None},
-			{None, None, None, None, None}}
-	case 11:
-		return [][]BoardElement{
-			{Cyan, Cyan, Cyan, None, None},
-			{Cyan, None, None, None, None},
-			{Cyan, None, None, None, None},
-			{None, None, None, None, None},
-			{None, None, None, None, None}}
-	case 12:
-		return [][]BoardElement{
-			{Cyan, Cyan, Cyan, None, None},
-			{None, None, Cyan, None, None},
-			{None, None, Cyan, None, None},
-			{None, None, None, None, None},
-			{None, None, None, None, None}}
-	case 13:
-		return [][]BoardElement{
-			{None, None, Cyan, None, None},
-			{None, None, Cyan, None, None},
-			{Cyan, Cyan, Cyan, None, None},
-			{None, None, None, None, None},
-			{None, None, None, None, None}}
-	case 14:
-		return [][]BoardElement{
-			{Cyan, None, None, None, None},
-			{Cyan, None, None, None, None},
-			{Cyan, Cyan, Cyan, None, None},
-			{None, None, None, None, None},
-			{None, None, None, None, None}}
-	case 15:
-		return [][]BoardElement{
-			{White, White, None, None, None},
-			{White, None, None, None, None},
-			{None, None, None, None, None},
-			{None, None, None, None, None},
-			{None, None, None, None, None}}
-	case 16:
-		return [][]BoardElement{
-			{White, White, None, None, None},
-			{None, White, None, None, None},
-			{None, None, None, None, None},
-			{None, None, None, None, None},
-			{None, None, None, None, None}}
-	case 17:
-		return [][]BoardElement{
-			{None, White, None, None, None},
-			{White, White, None, None, None},
-			{None, None, None, None, None},
-			{None, None, None, None, None},
-			{None, None, None, None, None}}
-	case 18:
-		return [][]BoardElement{
-			{White, None, None, None, None},
-			{White, White, None, None, None},
-			{None, None, None, None, None},
-			{None, None, None, None, None},
-			{None, None, None, None, None}}
-
-	default:
-		return [][]BoardElement{
-			{None, None, None, None, None},
-			{None, None, None, None, None},
-			{None, None, None, None, None},
-			{None, None, None, None, None},
-			{None, None, None, None, None}}
-	}
+	return defaultCatalogue.grid(number)
 }
 
 // createContainer returns square two diemnsional slice of size x size
 func createContainer(size int) [][]BoardElement {
-	board := make([][]BoardElement, size)
-	for i := 0; i < size; i++ {
-		board[i] = make([]BoardElement, size)
+	return createBoard(size, size)
+}
+
+// createBoard returns two dimensional slice of rows x columns
+func createBoard(rows int, columns int) [][]BoardElement {
+	board := make([][]BoardElement, rows)
+	for i := 0; i < rows; i++ {
+		board[i] = make([]BoardElement, columns)
 	}
 	return board
 }
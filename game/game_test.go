@@ -12,9 +12,9 @@ func TestInitialState(t *testing.T) {
 	g := New()
 
 	assert.True(testBlockEmptiness("Board", g.Board, 10, t), "Board must be empty")
-	assert.False(testBlockEmptiness("BlockA", g.BlockA, 5, t), "BlockA must not be empty")
-	assert.False(testBlockEmptiness("BlockB", g.BlockB, 5, t), "BlockB must not be empty")
-	assert.False(testBlockEmptiness("BlockC", g.BlockC, 5, t), "BlockC must not be empty")
+	assert.False(testBlockEmptiness("BlockA", g.Blocks[A], 5, t), "BlockA must not be empty")
+	assert.False(testBlockEmptiness("BlockB", g.Blocks[B], 5, t), "BlockB must not be empty")
+	assert.False(testBlockEmptiness("BlockC", g.Blocks[C], 5, t), "BlockC must not be empty")
 }
 
 func testBlockEmptiness(blockName string, block [][]BoardElement, size int, t *testing.T) bool {
@@ -38,14 +38,14 @@ func TestMove(t *testing.T) {
 
 	g := New()
 
-	g.BlockA = blockShape(1)
-	g.BlockB = blockShape(1)
-	g.BlockC = blockShape(1)
+	g.Blocks[A] = blockShape(1)
+	g.Blocks[B] = blockShape(1)
+	g.Blocks[C] = blockShape(1)
 
 	if error := g.Move(A, 0, 0); error != nil {
 		t.Errorf("First move of BlockA to position 0,0 must succeed. Received: %s", error)
 	}
-	assert.True(testBlockEmptiness("BlockA", g.BlockA, 5, t), "BlockA must be empty")
+	assert.True(testBlockEmptiness("BlockA", g.Blocks[A], 5, t), "BlockA must be empty")
 
 	if error := g.Move(A, 5, 5); error == nil {
 		t.Errorf("Second move of empty BlockA without moving B or C to position 5,5 must fail")
@@ -59,16 +59,36 @@ func TestMove(t *testing.T) {
 	if error := g.Move(B, 1, 0); error != nil {
 		t.Errorf("First move of BlockB to position 1,0 must succeed. Received: %s", error)
 	}
-	assert.True(testBlockEmptiness("BlockB", g.BlockA, 5, t), "BlockA must be empty")
+	assert.True(testBlockEmptiness("BlockB", g.Blocks[A], 5, t), "BlockA must be empty")
 
 	if error := g.Move(C, 2, 0); error != nil {
 		t.Errorf("First move of BlockC to position 2,0 must succeed. Received: %s", error)
 	}
 
 	// randomize all blocks again
-	assert.False(testBlockEmptiness("BlockA", g.BlockA, 5, t), "BlockA must not be empty")
-	assert.False(testBlockEmptiness("BlockB", g.BlockB, 5, t), "BlockB must not be empty")
-	assert.False(testBlockEmptiness("BlockC", g.BlockC, 5, t), "BlockC must not be empty")
+	assert.False(testBlockEmptiness("BlockA", g.Blocks[A], 5, t), "BlockA must not be empty")
+	assert.False(testBlockEmptiness("BlockB", g.Blocks[B], 5, t), "BlockB must not be empty")
+	assert.False(testBlockEmptiness("BlockC", g.Blocks[C], 5, t), "BlockC must not be empty")
+}
+
+func TestMoveOnValueCopy(t *testing.T) {
+	assert := assert.New(t)
+
+	config := DefaultConfig()
+	config.Hold = true
+	config.Rerolls = 1
+	g := NewWithOptions(WithConfig(config))
+	blocks := cloneContainers(g.Blocks)
+
+	c := g
+	assert.Nil(c.Move(A, 0, 0))
+	assert.Nil(c.Hold(B))
+	assert.Equal(blocks, g.Blocks, "blocks of the original game are not changed")
+	assert.True(isBlockEmpty(g.Held))
+
+	c = g
+	assert.Nil(c.Reroll())
+	assert.Equal(blocks, g.Blocks)
 }
 
 func TestMoveFullRow(t *testing.T) {
@@ -76,8 +96,8 @@ func TestMoveFullRow(t *testing.T) {
 
 	g := New()
 
-	g.BlockA = blockShape(8)
-	g.BlockB = blockShape(8)
+	g.Blocks[A] = blockShape(8)
+	g.Blocks[B] = blockShape(8)
 
 	if error := g.Move(A, 0, 0); error != nil {
 		t.Errorf("Move of BlockA to position 0,0 must succeed")
@@ -100,7 +120,7 @@ func TestCheckAndRemoveFullLanes(t *testing.T) {
 		g.Board[0][i] = Green
 	}
 
-	checkAndRemoveFullLanes(g.Board)
+	g.checkAndRemoveFullLanes(g.Board)
 
 	assert.True(testBlockEmptiness("Board", g.Board, 10, t), "Board must be empty")
 
@@ -109,7 +129,7 @@ func TestCheckAndRemoveFullLanes(t *testing.T) {
 		g.Board[i][0] = Magenta
 	}
 
-	checkAndRemoveFullLanes(g.Board)
+	g.checkAndRemoveFullLanes(g.Board)
 
 	assert.True(testBlockEmptiness("Board", g.Board, 10, t), "Board must be empty")
 
@@ -119,7 +139,7 @@ func TestCheckAndRemoveFullLanes(t *testing.T) {
 		g.Board[0][i] = Yellow
 	}
 
-	checkAndRemoveFullLanes(g.Board)
+	g.checkAndRemoveFullLanes(g.Board)
 
 	assert.True(testBlockEmptiness("Board", g.Board, 10, t), "Board must be empty")
 
@@ -131,7 +151,7 @@ func TestCheckAndRemoveFullLanes(t *testing.T) {
 	g.Board[9][8] = Blue
 	g.Board[9][9] = Blue
 
-	checkAndRemoveFullLanes(g.Board)
+	g.checkAndRemoveFullLanes(g.Board)
 
 	assert.Equal(Cyan, g.Board[0][0], "Board[0][0] is not equal to Cyan")
 	assert.Equal(Yellow, g.Board[0][1], "Board[0][0] is not equal to Yellow")
@@ -158,33 +178,33 @@ func TestGameOver(t *testing.T) {
 		{None, None, None, None, None, None, None, None, None, None},
 		{None, None, None, None, None, None, None, None, None, None}}
 
-	g.BlockA = blockShape(1)
-	g.BlockB = blockShape(1)
-	g.BlockC = blockShape(1)
+	g.Blocks[A] = blockShape(1)
+	g.Blocks[B] = blockShape(1)
+	g.Blocks[C] = blockShape(1)
 
 	assert.False(g.isGameOver())
 
-	g.BlockA = createContainer(5)
-	g.BlockB = blockShape(10)
-	g.BlockC = createContainer(5)
+	g.Blocks[A] = createContainer(5)
+	g.Blocks[B] = blockShape(10)
+	g.Blocks[C] = createContainer(5)
 
 	assert.True(g.isGameOver())
 
-	g.BlockA = blockShape(10)
-	g.BlockB = blockShape(10)
-	g.BlockC = blockShape(11)
+	g.Blocks[A] = blockShape(10)
+	g.Blocks[B] = blockShape(10)
+	g.Blocks[C] = blockShape(11)
 
 	assert.True(g.isGameOver())
 
-	g.BlockA = createContainer(5)
-	g.BlockB = createContainer(5)
-	g.BlockC = blockShape(1)
+	g.Blocks[A] = createContainer(5)
+	g.Blocks[B] = createContainer(5)
+	g.Blocks[C] = blockShape(1)
 
 	assert.False(g.isGameOver())
 
-	g.BlockA = blockShape(1)
-	g.BlockB = blockShape(10)
-	g.BlockC = createContainer(5)
+	g.Blocks[A] = blockShape(1)
+	g.Blocks[B] = blockShape(10)
+	g.Blocks[C] = createContainer(5)
 
 	error := g.Move(A, 8, 0)
 
@@ -234,7 +254,7 @@ func TestPlaceBlock(t *testing.T) {
 }
 
 func testPlacement(g *Game, x int, y int, block [][]BoardElement, blockName string, shouldSucceed bool, t *testing.T) {
-	if error := g.placeBlock(x, y, block); error != nil {
+	if _, error := g.placeBlock(x, y, block); error != nil {
 		if shouldSucceed {
 			t.Errorf("Placement of %s at %d,%d must not return error", blockName, x, y)
 		}
@@ -244,3 +264,68 @@ func testPlacement(g *Game, x int, y int, block [][]BoardElement, blockName stri
 		t.Errorf("Placement of %s at %d,%d must return error", blockName, x, y)
 	}
 }
+
+func TestNewWithSeed(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(New().Blocks[A], NewWithSeed(DefaultSeed).Blocks[A])
+	assert.Equal(int64(42), NewWithSeed(42).Seed())
+
+	g1 := NewWithSeed(7)
+	g2 := NewWithOptions(WithSeed(7))
+	for i := 0; i < 10; i++ {
+		g1.assignRandomBlocks()
+		g2.assignRandomBlocks()
+		assert.Equal(g1.Blocks[A], g2.Blocks[A])
+		assert.Equal(g1.Blocks[B], g2.Blocks[B])
+		assert.Equal(g1.Blocks[C], g2.Blocks[C])
+	}
+}
+
+func TestStones(t *testing.T) {
+	assert := assert.New(t)
+
+	board, err := ParseRows([]string{
+		"sssss",
+		"s....",
+		"s.s..",
+		"sssss",
+		".....",
+	})
+	assert.Nil(err)
+
+	for _, engine := range []Engine{SliceEngine, BitboardEngine} {
+		config := DefaultConfig()
+		config.Engine = engine
+		g := NewWithOptions(WithConfig(config), WithBoard(board))
+		assert.Equal(5, g.Config().Rows)
+		assert.Equal(5, g.Config().Columns)
+		assert.Equal(Stone, g.Board[2][2])
+		g.Blocks[A] = blockShape(5)
+		g.Blocks[B] = blockShape(3)
+		g.Blocks[C] = blockShape(0)
+
+		// lanes made only of stones are not removed
+		assert.Nil(g.Move(C, 4, 4))
+		assert.Equal(1, g.Score)
+		assert.Equal(Stone, g.Board[0][0])
+
+		// stones fill the lane but stay on the board
+		assert.False(g.CanPlace(A, 2, 1))
+		assert.Nil(g.Move(A, 1, 1))
+		assert.Equal(10, g.Score)
+		for y := 0; y < 5; y++ {
+			assert.Equal(Stone, g.Board[0][y])
+			assert.Equal(Stone, g.Board[3][y])
+		}
+		assert.Equal([]BoardElement{Stone, None, None, None, None}, g.Board[1])
+	}
+}
+
+func cloneContainers(containers [][][]BoardElement) [][][]BoardElement {
+	clone := make([][][]BoardElement, len(containers))
+	for i := range containers {
+		clone[i] = cloneContainer(containers[i])
+	}
+	return clone
+}
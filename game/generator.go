@@ -0,0 +1,136 @@
+package game
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// ShapesCount is the number of shapes in the default catalogue
+const ShapesCount = 19
+
+// BlockGenerator decides which shape is dealt as the next block. Shapes are
+// identified by their ID in the catalogue, in 0..shapesCount-1 range. All
+// randomness should be taken from the provided generator so that games remain
+// reproducible by seed. Clone returns independent copy of the generator
+// including its internal state, it is used when the game is cloned.
+type BlockGenerator interface {
+	NextShape(random *rand.Rand, shapesCount int) int
+	Clone() BlockGenerator
+}
+
+// UniformGenerator deals every shape with the same probability
+type UniformGenerator struct{}
+
+// NextShape returns random shape
+func (generator UniformGenerator) NextShape(random *rand.Rand, shapesCount int) int {
+	return random.Intn(shapesCount)
+}
+
+// Clone returns the same generator as it has no state
+func (generator UniformGenerator) Clone() BlockGenerator {
+	return generator
+}
+
+// WeightedGenerator deals shapes with probability proportional to weight
+// assigned to the shape, Weights[i] being the weight of shape i. Shapes
+// without weight are never dealt.
+type WeightedGenerator struct {
+	Weights []float64
+}
+
+// NextShape returns random shape taking weights into account
+func (generator WeightedGenerator) NextShape(random *rand.Rand, shapesCount int) int {
+	total := 0.0
+	for shape := 0; shape < len(generator.Weights) && shape < shapesCount; shape++ {
+		if generator.Weights[shape] > 0 {
+			total += generator.Weights[shape]
+		}
+	}
+	if total == 0 {
+		return random.Intn(shapesCount)
+	}
+
+	value := random.Float64() * total
+	last := 0
+	for shape := 0; shape < len(generator.Weights) && shape < shapesCount; shape++ {
+		if generator.Weights[shape] <= 0 {
+			continue
+		}
+		last = shape
+		value -= generator.Weights[shape]
+		if value < 0 {
+			return shape
+		}
+	}
+	return last
+}
+
+// Clone returns the same generator as it has no state
+func (generator WeightedGenerator) Clone() BlockGenerator {
+	return generator
+}
+
+// BagGenerator puts every shape once into a bag, shuffles it and deals shapes
+// from the bag until it is empty, then the next bag is prepared
+type BagGenerator struct {
+	bag []int
+}
+
+// NewBagGenerator returns BagGenerator with empty bag
+func NewBagGenerator() *BagGenerator {
+	return &BagGenerator{}
+}
+
+// NextShape returns next shape from the bag
+func (generator *BagGenerator) NextShape(random *rand.Rand, shapesCount int) int {
+	if len(generator.bag) == 0 {
+		generator.bag = random.Perm(shapesCount)
+	}
+	shape := generator.bag[0]
+	generator.bag = generator.bag[1:]
+	return shape
+}
+
+// Clone returns generator with copy of the current bag
+func (generator *BagGenerator) Clone() BlockGenerator {
+	clone := &BagGenerator{bag: make([]int, len(generator.bag))}
+	copy(clone.bag, generator.bag)
+	return clone
+}
+
+// ScriptedGenerator deals shapes in the provided order. When the sequence is
+// exhausted it starts again from the beginning.
+type ScriptedGenerator struct {
+	sequence []int
+	position int
+}
+
+// NewScriptedGenerator returns ScriptedGenerator dealing provided shapes
+func NewScriptedGenerator(sequence ...int) *ScriptedGenerator {
+	return &ScriptedGenerator{sequence: sequence}
+}
+
+// NextShape returns next shape from the sequence, random is not used
+func (generator *ScriptedGenerator) NextShape(random *rand.Rand, shapesCount int) int {
+	if len(generator.sequence) == 0 {
+		return 0
+	}
+	shape := generator.sequence[generator.position%len(generator.sequence)]
+	generator.position++
+	return shape
+}
+
+// Validate checks if all shapes of the sequence are in the catalogue
+func (generator *ScriptedGenerator) Validate(shapes *Catalogue) error {
+	for _, id := range generator.sequence {
+		if _, ok := shapes.Shape(id); !ok {
+			return fmt.Errorf("Shape %d of the scripted generator is not in catalogue %q", id, shapes.Name)
+		}
+	}
+	return nil
+}
+
+// Clone returns generator at the same position of the sequence
+func (generator *ScriptedGenerator) Clone() BlockGenerator {
+	return &ScriptedGenerator{sequence: generator.sequence, position: generator.position}
+}
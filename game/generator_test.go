@@ -0,0 +1,116 @@
+package game
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUniformGenerator(t *testing.T) {
+	assert := assert.New(t)
+
+	random := rand.New(rand.NewSource(1))
+	expected := rand.New(rand.NewSource(1))
+	generator := UniformGenerator{}
+
+	for i := 0; i < 100; i++ {
+		assert.Equal(expected.Intn(ShapesCount), generator.NextShape(random, ShapesCount))
+	}
+}
+
+func TestWeightedGenerator(t *testing.T) {
+	assert := assert.New(t)
+
+	random := rand.New(rand.NewSource(1))
+	generator := WeightedGenerator{Weights: []float64{0, 1, 0, 3}}
+
+	counts := make([]int, ShapesCount)
+	for i := 0; i < 4000; i++ {
+		counts[generator.NextShape(random, ShapesCount)]++
+	}
+
+	assert.Equal(0, counts[0])
+	assert.Equal(0, counts[2])
+	assert.Equal(4000, counts[1]+counts[3])
+	assert.InDelta(3.0, float64(counts[3])/float64(counts[1]), 0.3)
+}
+
+func TestBagGenerator(t *testing.T) {
+	assert := assert.New(t)
+
+	random := rand.New(rand.NewSource(1))
+	generator := NewBagGenerator()
+
+	for bag := 0; bag < 3; bag++ {
+		seen := make(map[int]bool)
+		for i := 0; i < ShapesCount; i++ {
+			seen[generator.NextShape(random, ShapesCount)] = true
+		}
+		assert.Equal(ShapesCount, len(seen), "every shape must be dealt once per bag")
+	}
+}
+
+func TestScriptedGenerator(t *testing.T) {
+	assert := assert.New(t)
+
+	generator := NewScriptedGenerator(4, 2, 9)
+
+	assert.Equal(4, generator.NextShape(nil, ShapesCount))
+	assert.Equal(2, generator.NextShape(nil, ShapesCount))
+	assert.Equal(9, generator.NextShape(nil, ShapesCount))
+	assert.Equal(4, generator.NextShape(nil, ShapesCount))
+
+	// unknown shapes are rejected instead of dealing empty blocks
+	assert.Nil(generator.Validate(DefaultCatalogue()))
+	unknown := NewScriptedGenerator(4, ShapesCount)
+	assert.NotNil(unknown.Validate(DefaultCatalogue()))
+	assert.NotNil(NewScriptedGenerator(-1).Validate(DefaultCatalogue()))
+	assert.Panics(func() { NewWithOptions(WithGenerator(unknown)) })
+	_, err := ParseNotation("10/10/10/10/10/10/10/10/10/10 -,-,- 0 p 1:0", WithGenerator(unknown))
+	assert.NotNil(err)
+
+	data, err := New().MarshalJSON()
+	assert.Nil(err)
+	data = []byte(strings.Replace(string(data), `"type":"uniform"`, `"type":"scripted","sequence":[4,19]`, 1))
+	var g Game
+	assert.NotNil(g.UnmarshalJSON(data))
+}
+
+func TestGameWithGenerator(t *testing.T) {
+	assert := assert.New(t)
+
+	g := NewWithOptions(WithGenerator(NewScriptedGenerator(1, 1, 1, 9)))
+
+	assert.Equal(blockShape(1), g.Blocks[A])
+	assert.Equal(blockShape(1), g.Blocks[B])
+	assert.Equal(blockShape(1), g.Blocks[C])
+
+	assert.Nil(g.Move(A, 0, 0))
+	assert.Nil(g.Move(B, 1, 0))
+	assert.Nil(g.Move(C, 2, 0))
+
+	assert.Equal(blockShape(9), g.Blocks[A])
+	assert.Equal(blockShape(1), g.Blocks[B])
+	assert.Equal(blockShape(1), g.Blocks[C])
+}
+
+func TestGeneratorClone(t *testing.T) {
+	assert := assert.New(t)
+
+	random := rand.New(rand.NewSource(1))
+	bag := NewBagGenerator()
+	bag.NextShape(random, ShapesCount)
+	bagClone := bag.Clone()
+	cloneRandom := rand.New(rand.NewSource(2))
+	for i := 0; i < ShapesCount-1; i++ {
+		assert.Equal(bag.NextShape(random, ShapesCount), bagClone.NextShape(cloneRandom, ShapesCount))
+	}
+
+	scripted := NewScriptedGenerator(1, 2, 3)
+	scripted.NextShape(nil, ShapesCount)
+	scriptedClone := scripted.Clone()
+	assert.Equal(2, scriptedClone.NextShape(nil, ShapesCount))
+	assert.Equal(2, scripted.NextShape(nil, ShapesCount))
+}
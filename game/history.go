@@ -0,0 +1,110 @@
+package game
+
+// history keeps states of the game before each move so that moves can be
+// undone and redone
+type history struct {
+	undo []historyEntry
+	redo []historyEntry
+}
+
+// historyEntry is a state of the game before the move was made
+type historyEntry struct {
+	state Game
+	move  Move
+}
+
+// WithHistory enables history of moves needed by Undo and Redo
+func WithHistory() Option {
+	return func(s *settings) {
+		s.history = true
+	}
+}
+
+// EnableHistory starts keeping history of moves in the game created without
+// WithHistory option, eg. decoded from JSON
+func (g *Game) EnableHistory() {
+	if g.history == nil {
+		g.history = &history{}
+	}
+}
+
+// HistoryEnabled checks if the game keeps history of moves
+func (g Game) HistoryEnabled() bool {
+	return g.history != nil
+}
+
+// Moves returns moves made since the beginning of the game, without the
+// undone ones. Nil is returned when history is not enabled.
+func (g Game) Moves() []Move {
+	if g.history == nil {
+		return nil
+	}
+	moves := make([]Move, len(g.history.undo))
+	for i, entry := range g.history.undo {
+		moves[i] = entry.move
+	}
+	return moves
+}
+
+// Undo restores the game to the state before the last move: board, blocks,
+// score and position of the random generator. Undone move can be made again
+// with Redo until a new move is made.
+func (g *Game) Undo() error {
+	if g.history == nil {
+		return &ErrorGame{NoHistory, "History is not enabled"}
+	}
+	if len(g.history.undo) == 0 {
+		return &ErrorGame{NoHistory, "There is no move to undo"}
+	}
+
+	last := len(g.history.undo) - 1
+	entry := g.history.undo[last]
+	g.history.undo = g.history.undo[:last]
+	g.history.redo = append(g.history.redo, historyEntry{g.cloneState(), entry.move})
+	g.restore(entry.state)
+	return nil
+}
+
+// Redo makes again the last undone move
+func (g *Game) Redo() error {
+	if g.history == nil {
+		return &ErrorGame{NoHistory, "History is not enabled"}
+	}
+	if len(g.history.redo) == 0 {
+		return &ErrorGame{NoHistory, "There is no move to redo"}
+	}
+
+	last := len(g.history.redo) - 1
+	entry := g.history.redo[last]
+	g.history.redo = g.history.redo[:last]
+	g.history.undo = append(g.history.undo, historyEntry{g.cloneState(), entry.move})
+	g.restore(entry.state)
+	return nil
+}
+
+// record stores state of the game before the move, any undone moves are
+// forgotten
+func (g *Game) record(state Game, move Move) {
+	g.history.undo = append(g.history.undo, historyEntry{state, move})
+	g.history.redo = nil
+}
+
+// restore replaces state of the game with a copy of provided state keeping
+// the history and observers
+func (g *Game) restore(state Game) {
+	history, observers := g.history, g.observers
+	*g = state.cloneState()
+	g.history, g.observers = history, observers
+}
+
+// clone returns history which can be modified independently, stored states
+// are never modified so they are shared
+func (h *history) clone() *history {
+	clone := &history{
+		undo: make([]historyEntry, len(h.undo)),
+		redo: make([]historyEntry, len(h.redo)),
+	}
+	copy(clone.undo, h.undo)
+	copy(clone.redo, h.redo)
+	return clone
+}
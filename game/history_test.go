@@ -0,0 +1,111 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUndoRedo(t *testing.T) {
+	assert := assert.New(t)
+
+	g := NewWithOptions(WithHistory(), WithSeed(3))
+	assert.True(g.HistoryEnabled())
+	initial := g.Clone()
+
+	moves := g.LegalMoves()
+	assert.Nil(g.Move(moves[0].Block, moves[0].X, moves[0].Y))
+	afterFirst := g.Clone()
+	moves = g.LegalMoves()
+	assert.Nil(g.Move(moves[len(moves)-1].Block, moves[len(moves)-1].X, moves[len(moves)-1].Y))
+	moves = g.LegalMoves()
+	assert.Nil(g.Move(moves[0].Block, moves[0].X, moves[0].Y))
+	afterThird := g.Clone()
+	assert.Equal(3, len(g.Moves()))
+
+	assert.Nil(g.Undo())
+	assert.Nil(g.Undo())
+	assert.Equal(afterFirst.Board, g.Board)
+	assert.Equal(afterFirst.Blocks, g.Blocks)
+	assert.Equal(afterFirst.Score, g.Score)
+	assert.Equal(1, len(g.Moves()))
+
+	assert.Nil(g.Redo())
+	assert.Nil(g.Redo())
+	assert.Equal(afterThird.Board, g.Board)
+	assert.Equal(afterThird.Blocks, g.Blocks)
+	assert.Equal(afterThird.Score, g.Score)
+	assert.Equal(NoHistory, g.Redo().(*ErrorGame).Reason)
+
+	// random generator is restored so the same blocks are dealt again
+	assert.Nil(g.Undo())
+	assert.Nil(g.Undo())
+	assert.Nil(g.Undo())
+	assert.Equal(initial.Board, g.Board)
+	assert.Equal(initial.Blocks, g.Blocks)
+	assert.Equal(NoHistory, g.Undo().(*ErrorGame).Reason)
+	for i := 0; i < 5; i++ {
+		initial.assignRandomBlocks()
+		g.assignRandomBlocks()
+		assert.Equal(initial.Blocks, g.Blocks)
+	}
+}
+
+func TestNewMoveClearsRedo(t *testing.T) {
+	assert := assert.New(t)
+
+	g := NewWithOptions(WithHistory())
+	g.Blocks[A] = blockShape(0)
+	g.Blocks[B] = blockShape(0)
+
+	assert.Nil(g.Move(A, 0, 0))
+	assert.Nil(g.Undo())
+	assert.Nil(g.Move(B, 5, 5))
+	assert.Equal([]Move{{B, 5, 5, PlaceBlock}}, g.Moves())
+	assert.NotNil(g.Redo())
+}
+
+func TestUndoGameOver(t *testing.T) {
+	assert := assert.New(t)
+
+	g := NewWithOptions(WithHistory())
+	g.Blocks[A] = blockShape(0)
+
+	assert.NotNil(g.Move(A, -1, 0))
+	assert.True(g.GameOver)
+	assert.NotNil(g.Undo(), "invalid move is not recorded")
+	g.GameOver = false
+
+	assert.Nil(g.Move(A, 0, 0))
+	g.GameOver = true
+	assert.Nil(g.Undo())
+	assert.False(g.GameOver)
+}
+
+func TestHistoryDisabled(t *testing.T) {
+	assert := assert.New(t)
+
+	g := New()
+	assert.False(g.HistoryEnabled())
+	assert.Nil(g.Moves())
+	assert.Equal(NoHistory, g.Undo().(*ErrorGame).Reason)
+	assert.Equal(NoHistory, g.Redo().(*ErrorGame).Reason)
+
+	g.EnableHistory()
+	assert.True(g.HistoryEnabled())
+	assert.Empty(g.Moves())
+}
+
+func TestCloneHistory(t *testing.T) {
+	assert := assert.New(t)
+
+	g := NewWithOptions(WithHistory())
+	g.Blocks[A] = blockShape(0)
+	assert.Nil(g.Move(A, 0, 0))
+
+	clone := g.Clone()
+	assert.Nil(clone.Undo())
+	assert.Equal(1, len(g.Moves()))
+	assert.Equal(Red, g.Board[0][0])
+	assert.Equal(None, clone.Board[0][0])
+}
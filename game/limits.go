@@ -0,0 +1,104 @@
+package game
+
+import (
+	"time"
+)
+
+// Clock tells the current time, it measures the time limit of the game
+type Clock interface {
+	Now() time.Time
+}
+
+// SystemClock is the Clock returning the wall-clock time
+type SystemClock struct{}
+
+// Now returns the current local time
+func (SystemClock) Now() time.Time {
+	return time.Now()
+}
+
+// ManualClock is the Clock which moves only when advanced, eg. in tests
+type ManualClock struct {
+	now time.Time
+}
+
+// NewManualClock returns ManualClock set to a given time
+func NewManualClock(now time.Time) *ManualClock {
+	return &ManualClock{now: now}
+}
+
+// Now returns the time set by NewManualClock and Advance
+func (clock *ManualClock) Now() time.Time {
+	return clock.now
+}
+
+// Advance moves the clock forward by a given duration
+func (clock *ManualClock) Advance(duration time.Duration) {
+	clock.now = clock.now.Add(duration)
+}
+
+// WithClock sets the clock measuring the time limit of the game, SystemClock
+// is used by default
+func WithClock(clock Clock) Option {
+	return func(s *settings) {
+		s.clock = clock
+	}
+}
+
+// SetClock replaces the clock measuring the time limit of the game, eg. the
+// clock of a decoded or copied game. Time already used is kept.
+func (g *Game) SetClock(clock Clock) {
+	elapsed := g.Elapsed()
+	g.clock = clock
+	g.started = clock.Now().Add(-elapsed)
+}
+
+// MovesMade returns the number of blocks placed since the beginning of the
+// game. Other actions, like hold or reroll, are not counted.
+func (g Game) MovesMade() int {
+	return g.moves
+}
+
+// MovesLeft returns the number of blocks which can still be placed before
+// the move limit is reached, second value is false when there is no limit
+func (g Game) MovesLeft() (int, bool) {
+	if g.config.MoveLimit == 0 {
+		return 0, false
+	}
+	if g.moves >= g.config.MoveLimit {
+		return 0, true
+	}
+	return g.config.MoveLimit - g.moves, true
+}
+
+// Elapsed returns the time passed since the beginning of the game
+func (g Game) Elapsed() time.Duration {
+	if g.clock == nil {
+		return 0
+	}
+	return g.clock.Now().Sub(g.started)
+}
+
+// TimeLeft returns the time remaining before the time limit is reached,
+// second value is false when there is no limit
+func (g Game) TimeLeft() (time.Duration, bool) {
+	if g.config.TimeLimit == 0 {
+		return 0, false
+	}
+	if left := g.config.TimeLimit - g.Elapsed(); left > 0 {
+		return left, true
+	}
+	return 0, true
+}
+
+// limitReached checks if the move or time limit of the game is reached and
+// returns message describing the reached limit
+func (g Game) limitReached() (string, bool) {
+	if left, ok := g.MovesLeft(); ok && left == 0 {
+		return "Move limit reached. Game over.", true
+	}
+	if left, ok := g.TimeLeft(); ok && left == 0 {
+		return "Time limit reached. Game over.", true
+	}
+	return "", false
+}
@@ -0,0 +1,100 @@
+package game
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMoveLimit(t *testing.T) {
+	assert := assert.New(t)
+
+	config := DefaultConfig()
+	config.MoveLimit = 2
+	config.Rotation = true
+	g := NewWithOptions(WithConfig(config), WithHistory(), WithGenerator(NewScriptedGenerator(3, 9, 0)))
+
+	left, ok := g.MovesLeft()
+	assert.True(ok)
+	assert.Equal(2, left)
+
+	// actions other than placement are not counted
+	assert.Nil(g.Rotate(A))
+	assert.Nil(g.Move(A, 0, 0))
+	assert.Equal(1, g.MovesMade())
+	assert.NotNil(g.Move(B, 0, 0))
+	assert.Equal(1, g.MovesMade(), "invalid move is not counted")
+	g.GameOver = false
+
+	err := g.Move(B, 5, 5)
+	assert.Equal(&ErrorGame{GameOver, "Move limit reached. Game over."}, err)
+	assert.True(g.GameOver)
+	left, _ = g.MovesLeft()
+	assert.Equal(0, left)
+
+	assert.Nil(g.Undo())
+	assert.False(g.GameOver)
+	assert.Equal(1, g.MovesMade())
+
+	_, ok = NewWithOptions().MovesLeft()
+	assert.False(ok)
+}
+
+func TestTimeLimit(t *testing.T) {
+	assert := assert.New(t)
+
+	config := DefaultConfig()
+	config.TimeLimit = time.Minute
+	clock := NewManualClock(time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC))
+	stats := &Stats{}
+	g := NewWithOptions(WithConfig(config), WithClock(clock), WithObserver(stats))
+
+	assert.Nil(g.Move(A, 0, 0))
+	clock.Advance(40 * time.Second)
+	left, ok := g.TimeLeft()
+	assert.True(ok)
+	assert.Equal(20*time.Second, left)
+	assert.Equal(40*time.Second, g.Elapsed())
+
+	// new clock continues from the time already used
+	c := g.Clone()
+	c.SetClock(NewManualClock(time.Time{}))
+	clock.Advance(time.Hour)
+	assert.Equal(40*time.Second, c.Elapsed())
+	clock.Advance(-time.Hour)
+
+	assert.Nil(g.Move(B, 5, 5))
+	clock.Advance(20 * time.Second)
+	err := g.Move(C, 0, 5)
+	assert.Equal(&ErrorGame{GameOver, "Time limit reached. Game over."}, err)
+	assert.True(g.GameOver)
+	assert.Equal(2, g.MovesMade())
+	assert.Equal(2, stats.Moves)
+
+	_, ok = NewWithOptions().TimeLeft()
+	assert.False(ok)
+}
+
+func TestLimitsJSON(t *testing.T) {
+	assert := assert.New(t)
+
+	config := DefaultConfig()
+	config.MoveLimit = 10
+	config.TimeLimit = time.Hour
+	clock := NewManualClock(time.Now())
+	g := NewWithOptions(WithConfig(config), WithClock(clock))
+	g.Move(A, 0, 0)
+	clock.Advance(15 * time.Minute)
+
+	data, err := json.Marshal(g)
+	assert.Nil(err)
+
+	var decoded Game
+	assert.Nil(json.Unmarshal(data, &decoded))
+	assert.Equal(config, decoded.Config())
+	assert.Equal(1, decoded.MovesMade())
+	left, _ := decoded.TimeLeft()
+	assert.InDelta(float64(45*time.Minute), float64(left), float64(time.Second))
+}
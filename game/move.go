@@ -0,0 +1,163 @@
+package game
+
+import (
+	"math/rand"
+)
+
+// Move describes placement of the block at x,y position of the board or,
+// when rule extensions are enabled, other action made with the block
+type Move struct {
+	Block  BlockType `json:"block"`
+	X      int       `json:"x"`
+	Y      int       `json:"y"`
+	Action Action    `json:"action,omitempty"`
+}
+
+// MoveResult describes changes made by a move
+type MoveResult struct {
+	Move Move
+	// CellsPlaced is the number of non empty cells of the placed block
+	CellsPlaced int
+	// RowsCleared and ColumnsCleared contain indexes of removed lanes
+	RowsCleared    []int
+	ColumnsCleared []int
+	// ScoreDelta is the number of points received for the move and
+	// Breakdown shows how they were counted
+	ScoreDelta int
+	Breakdown  ScoreBreakdown
+	// Streak is the number of consecutive moves which removed lanes, this
+	// move included
+	Streak int
+	// BlocksDealt is true when the move used the last block and new blocks
+	// were dealt
+	BlocksDealt bool
+	// GameOver is true when no other move is possible after the move
+	GameOver bool
+}
+
+// LinesCleared returns the number of removed rows and columns
+func (result MoveResult) LinesCleared() int {
+	return len(result.RowsCleared) + len(result.ColumnsCleared)
+}
+
+// CanPlace checks if the block can be placed at x,y position of the board.
+// False is returned for game in a game over state, incorrect or empty block.
+func (g Game) CanPlace(block BlockType, x int, y int) bool {
+	if g.GameOver {
+		return false
+	}
+	if x < 0 || y < 0 || x >= len(g.Board) || y >= len(g.Board[0]) {
+		return false
+	}
+	selectedBlock, err := g.selectedBlock(block)
+	if err != nil {
+		return false
+	}
+	return g.isMovePossible(selectedBlock, x, y)
+}
+
+// LegalMoves returns all placements of all available blocks which are
+// possible on the current board, ordered by block, x and y with the held
+// block being the last one. Other actions are returned by LegalActions.
+func (g Game) LegalMoves() []Move {
+	var moves []Move
+	if g.GameOver {
+		return moves
+	}
+	if g.masks != nil {
+		return g.legalMovesBitboard()
+	}
+	for _, blockType := range g.BlockTypes() {
+		block, _ := g.Block(blockType)
+		if isBlockEmpty(block) {
+			continue
+		}
+		offsets := g.shapes.offsetsOf(block)
+		for x := 0; x+offsets.rows <= len(g.Board); x++ {
+			for y := 0; y+offsets.columns <= len(g.Board[x]); y++ {
+				if offsets.fits(g.Board, x, y) {
+					moves = append(moves, Move{blockType, x, y, PlaceBlock})
+				}
+			}
+		}
+	}
+	return moves
+}
+
+// Clone returns deep copy of the game. Moves made on the clone, including
+// blocks dealt by the generator, do not affect the original game. Observers
+// are not copied.
+func (g Game) Clone() Game {
+	clone := g.cloneState()
+	if g.history != nil {
+		clone.history = g.history.clone()
+	}
+	return clone
+}
+
+// cloneState returns deep copy of the game without its history
+func (g Game) cloneState() Game {
+	clone := g
+	clone.history = nil
+	clone.observers = nil
+	// the clone does not change the cache it shares with the game
+	clone.placeableVersion = -1
+	clone.Board = cloneContainer(g.Board)
+	if g.bits.board != nil && g.bits.board == &g.Board[0] {
+		clone.bits.board = &clone.Board[0]
+	}
+	clone.Blocks = make([][][]BoardElement, len(g.Blocks))
+	for i := range g.Blocks {
+		clone.Blocks[i] = cloneContainer(g.Blocks[i])
+	}
+	if g.Held != nil {
+		clone.Held = cloneContainer(g.Held)
+	}
+	if g.random != nil {
+		clone.random = g.random.clone()
+		clone.randomGenerator = rand.New(clone.random)
+	}
+	if g.generator != nil {
+		clone.generator = g.generator.Clone()
+	}
+	return clone
+}
+
+// Simulate makes the move on a copy of the game and returns the copy together
+// with details of the move. The game itself is not modified. Error is returned
+// only when the move is not possible, in such case returned game is an
+// unchanged copy regardless of the InvalidMovePolicy. Move which ends the game
+// is not treated as an error, it is reported by MoveResult.GameOver.
+func (g Game) Simulate(move Move) (Game, MoveResult, error) {
+	next := g.Clone()
+	if next.GameOver {
+		return next, MoveResult{Move: move}, &ErrorGame{GameOver, "Cannot continue playing game in a game over state"}
+	}
+	result, err := next.play(move)
+	return next, result, err
+}
+
+// Preview makes the move on a copy of the game in the same way as Simulate,
+// but new blocks are not dealt when the last block is used, as they are not
+// known to the player before the deal. Blocks of the copy stay empty and
+// MoveResult.BlocksDealt reports the deal. Game over caused by the next
+// blocks is not reported and reroll cannot be previewed. The copy does not
+// keep history of moves, it is meant to plan moves.
+func (g Game) Preview(move Move) (Game, MoveResult, error) {
+	next := g.cloneState()
+	if next.GameOver {
+		return next, MoveResult{Move: move}, &ErrorGame{GameOver, "Cannot continue playing game in a game over state"}
+	}
+	result, err := next.makeMove(move, false)
+	return next, result, err
+}
+
+// cloneContainer returns deep copy of two dimensional slice
+func cloneContainer(container [][]BoardElement) [][]BoardElement {
+	clone := make([][]BoardElement, len(container))
+	for i := range container {
+		clone[i] = make([]BoardElement, len(container[i]))
+		copy(clone[i], container[i])
+	}
+	return clone
+}
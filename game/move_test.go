@@ -0,0 +1,181 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanPlace(t *testing.T) {
+	assert := assert.New(t)
+
+	g := New()
+	g.Blocks[A] = blockShape(9)
+	g.Blocks[B] = createContainer(5)
+	g.Board[0][0] = Red
+
+	assert.False(g.CanPlace(A, 0, 0))
+	assert.True(g.CanPlace(A, 0, 1))
+	assert.True(g.CanPlace(A, 8, 8))
+	assert.False(g.CanPlace(A, 9, 8))
+	assert.False(g.CanPlace(A, -1, 0))
+	assert.False(g.CanPlace(A, 10, 0))
+	assert.False(g.CanPlace(B, 5, 5))
+	assert.False(g.CanPlace(3, 5, 5))
+
+	g.GameOver = true
+	assert.False(g.CanPlace(A, 0, 1))
+}
+
+func TestLegalMoves(t *testing.T) {
+	assert := assert.New(t)
+
+	g := New()
+	g.Blocks[A] = blockShape(10)
+	g.Blocks[B] = blockShape(8)
+	g.Blocks[C] = createContainer(5)
+
+	moves := g.LegalMoves()
+
+	// 3x3 square fits at 8x8 positions and five vertical at 6x10
+	assert.Equal(8*8+6*10, len(moves))
+	assert.Equal(Move{A, 0, 0, PlaceBlock}, moves[0])
+	assert.Equal(Move{B, 5, 9, PlaceBlock}, moves[len(moves)-1])
+	for _, move := range moves {
+		assert.True(g.CanPlace(move.Block, move.X, move.Y))
+	}
+
+	g.GameOver = true
+	assert.Empty(g.LegalMoves())
+}
+
+func TestLegalMovesWithEmptyCorner(t *testing.T) {
+	assert := assert.New(t)
+
+	g := New()
+	g.Board = createContainer(3)
+	g.Board[0][0] = Red
+	g.Board[0][1] = Red
+	g.Board[1][0] = Red
+	g.Board[1][1] = Red
+	g.Blocks[A] = blockShape(17)
+	g.Blocks[B] = createContainer(5)
+	g.Blocks[C] = createContainer(5)
+
+	assert.Equal([]Move{{A, 1, 1, PlaceBlock}}, g.LegalMoves())
+	assert.False(g.isGameOver())
+}
+
+func TestClone(t *testing.T) {
+	assert := assert.New(t)
+
+	g := NewWithOptions(WithGenerator(NewBagGenerator()))
+	g.Move(A, 0, 0)
+
+	clone := g.Clone()
+	assert.Equal(g.Board, clone.Board)
+	assert.Equal(g.Blocks, clone.Blocks)
+
+	clone.Board[5][5] = Red
+	clone.Blocks[B][0][0] = Stone
+	assert.Equal(None, g.Board[5][5])
+	assert.NotEqual(Stone, g.Blocks[B][0][0])
+
+	// both games deal the same blocks independently
+	for i := 0; i < 10; i++ {
+		g.assignRandomBlocks()
+		clone.assignRandomBlocks()
+		assert.Equal(g.Blocks, clone.Blocks)
+	}
+}
+
+func TestSimulate(t *testing.T) {
+	assert := assert.New(t)
+
+	g := New()
+	g.Blocks[A] = blockShape(7)
+	g.Blocks[B] = blockShape(7)
+	g.Blocks[C] = blockShape(0)
+	g.Board[3][0] = Red
+
+	next, result, err := g.Simulate(Move{A, 0, 0, PlaceBlock})
+	assert.Nil(err)
+	assert.Equal(0, g.Score)
+	assert.True(isBlockEmpty(next.Blocks[A]))
+	assert.False(isBlockEmpty(g.Blocks[A]))
+	assert.Equal(5, result.CellsPlaced)
+	assert.Equal(5, result.ScoreDelta)
+
+	next, result, err = next.Simulate(Move{B, 0, 5, PlaceBlock})
+	assert.Nil(err)
+	assert.Equal([]int{0}, result.RowsCleared)
+	assert.Empty(result.ColumnsCleared)
+	assert.Equal(1, result.LinesCleared())
+	assert.Equal(15, result.ScoreDelta)
+	assert.Equal(20, next.Score)
+	assert.False(result.BlocksDealt)
+
+	next, result, err = next.Simulate(Move{C, 9, 9, PlaceBlock})
+	assert.Nil(err)
+	assert.True(result.BlocksDealt)
+
+	// invalid move does not end the game
+	invalid, result, err := g.Simulate(Move{A, 3, 0, PlaceBlock})
+	assert.NotNil(err)
+	assert.Equal(IncorrectPosition, err.(*ErrorGame).Reason)
+	assert.False(invalid.GameOver)
+	assert.Equal(g.Board, invalid.Board)
+	assert.Equal(0, result.CellsPlaced)
+	assert.False(g.GameOver)
+}
+
+func TestSimulateGameOver(t *testing.T) {
+	assert := assert.New(t)
+
+	g := New()
+	for x := 0; x < 10; x++ {
+		for y := 0; y < 10; y++ {
+			if (x+y)%2 == 0 {
+				g.Board[x][y] = Green
+			}
+		}
+	}
+	g.Board[0][1] = Green
+	g.Blocks[A] = blockShape(0)
+	g.Blocks[B] = blockShape(9)
+	g.Blocks[C] = createContainer(5)
+
+	next, result, err := g.Simulate(Move{A, 1, 0, PlaceBlock})
+	assert.Nil(err)
+	assert.True(result.GameOver)
+	assert.True(next.GameOver)
+	assert.False(g.GameOver)
+
+	_, _, err = next.Simulate(Move{B, 5, 5, PlaceBlock})
+	assert.Equal(GameOver, err.(*ErrorGame).Reason)
+}
+
+func TestPreview(t *testing.T) {
+	assert := assert.New(t)
+
+	config := DefaultConfig()
+	config.Blocks = 1
+	config.Rerolls = 1
+	g := NewWithOptions(WithConfig(config), WithHistory(), WithGenerator(NewScriptedGenerator(0, 10)))
+
+	next, result, err := g.Preview(Move{A, 0, 0, PlaceBlock})
+	assert.Nil(err)
+	assert.True(result.BlocksDealt)
+	assert.False(result.GameOver)
+	assert.True(isBlockEmpty(next.Blocks[A]), "next block is not known")
+	assert.Equal(Red, next.Board[0][0])
+	assert.False(next.HistoryEnabled())
+	assert.Equal(None, g.Board[0][0])
+	assert.Equal(blockShape(0), g.Blocks[A])
+
+	_, _, err = g.Preview(Move{Action: Reroll})
+	assert.Equal(IncorrectAction, err.(*ErrorGame).Reason)
+
+	simulated, _, _ := g.Simulate(Move{A, 0, 0, PlaceBlock})
+	assert.Equal(blockShape(10), simulated.Blocks[A])
+}
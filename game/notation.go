@@ -0,0 +1,297 @@
+package game
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// elementSymbols are characters used for board elements in the notation
+const elementSymbols = ".rgybmcws"
+
+// Notation returns compact text description of the game made of five or six
+// fields separated by spaces:
+//   - board, rows separated by '/', each cell described by a letter of its
+//     color (r, g, y, b, m, c, w) or s for a stone and runs of empty cells by
+//     their count
+//   - blocks separated by ',' in the same format as the board, trimmed to the
+//     filled cells, '-' marks an empty block; the held block follows '|'
+//     when the hold slot is enabled
+//   - score
+//   - 'p' when the game is in progress or 'o' when it is over
+//   - seed and position of the random generator separated by ':'
+//   - streak of moves removing lanes, present only when it is not 0
+//
+// eg. "10/10/rr8/10/10/10/10/10/10/10 ww/w,-,g/g 4 p 1:3". Generator, shapes
+// and rules other than size of the board, number of blocks and the hold slot
+// are not part of the notation. Number of rerolls left is not stored either,
+// it is taken from the config.
+func (g Game) Notation() string {
+	blocks := make([]string, len(g.Blocks))
+	for i, block := range g.Blocks {
+		blocks[i] = blockNotation(block)
+	}
+	blocksField := strings.Join(blocks, ",")
+	if g.Held != nil {
+		blocksField += "|" + blockNotation(g.Held)
+	}
+
+	state := "p"
+	if g.GameOver {
+		state = "o"
+	}
+
+	var draws uint64
+	if g.random != nil {
+		draws = g.random.draws
+	}
+
+	notation := fmt.Sprintf("%s %s %d %s %d:%d", containerNotation(g.Board), blocksField, g.Score, state, g.seed, draws)
+	if g.streak > 0 {
+		notation += fmt.Sprintf(" %d", g.streak)
+	}
+	return notation
+}
+
+// ParseNotation creates Game from the text returned by Notation. Options are
+// applied before the position is restored, they can be used to set the
+// generator, shapes and rules not covered by the notation. Rows of the board
+// cannot be longer than the number of columns in the config, which allows to
+// parse boards wider than the default one only with a config set by options.
+func ParseNotation(notation string, options ...Option) (Game, error) {
+	fields := strings.Fields(notation)
+	if len(fields) != 5 && len(fields) != 6 {
+		return Game{}, fmt.Errorf("Notation must contain 5 or 6 fields, found %d", len(fields))
+	}
+
+	settings := defaultSettings()
+	for _, option := range options {
+		option(&settings)
+	}
+	columns := settings.config.normalized().Columns
+	blockColumns := columns
+	if blockColumns < blockSize {
+		blockColumns = blockSize
+	}
+
+	board, err := parseContainerNotation(fields[0], columns)
+	if err != nil {
+		return Game{}, err
+	}
+	if len(board) == 0 || len(board[0]) == 0 {
+		return Game{}, fmt.Errorf("Board must not be empty")
+	}
+	for _, row := range board {
+		if len(row) != len(board[0]) {
+			return Game{}, fmt.Errorf("All rows of the board must have the same length")
+		}
+	}
+
+	blocksFields := strings.Split(fields[1], "|")
+	if len(blocksFields) > 2 {
+		return Game{}, fmt.Errorf("Incorrect blocks %q", fields[1])
+	}
+	var held [][]BoardElement
+	if len(blocksFields) == 2 {
+		held, err = parseBlockNotation(blocksFields[1], blockColumns)
+		if err != nil {
+			return Game{}, err
+		}
+	}
+	blocksFields = strings.Split(blocksFields[0], ",")
+	blocks := make([][][]BoardElement, len(blocksFields))
+	for i, blockField := range blocksFields {
+		blocks[i], err = parseBlockNotation(blockField, blockColumns)
+		if err != nil {
+			return Game{}, err
+		}
+	}
+
+	score, err := strconv.Atoi(fields[2])
+	if err != nil {
+		return Game{}, fmt.Errorf("Incorrect score %q", fields[2])
+	}
+
+	if fields[3] != "p" && fields[3] != "o" {
+		return Game{}, fmt.Errorf("Incorrect state %q", fields[3])
+	}
+
+	randomFields := strings.Split(fields[4], ":")
+	if len(randomFields) != 2 {
+		return Game{}, fmt.Errorf("Incorrect random generator position %q", fields[4])
+	}
+	seed, err := strconv.ParseInt(randomFields[0], 10, 64)
+	if err != nil {
+		return Game{}, fmt.Errorf("Incorrect seed %q", randomFields[0])
+	}
+	draws, err := strconv.ParseUint(randomFields[1], 10, 64)
+	if err != nil {
+		return Game{}, fmt.Errorf("Incorrect random generator position %q", randomFields[1])
+	}
+
+	streak := 0
+	if len(fields) == 6 {
+		streak, err = strconv.Atoi(fields[5])
+		if err != nil || streak < 0 {
+			return Game{}, fmt.Errorf("Incorrect streak %q", fields[5])
+		}
+	}
+
+	settings.seed = seed
+	settings.config.Rows = len(board)
+	settings.config.Columns = len(board[0])
+	settings.config.Blocks = len(blocks)
+	settings.config.Hold = held != nil
+
+	if err := settings.validate(); err != nil {
+		return Game{}, err
+	}
+
+	g := newGame(settings)
+	g.Board = board
+	g.Blocks = blocks
+	g.Held = held
+	g.Score = score
+	g.streak = streak
+	g.GameOver = fields[3] == "o"
+	g.setRandomPosition(seed, draws)
+	return g, nil
+}
+
+func blockNotation(block [][]BoardElement) string {
+	notation := containerNotation(trimmedContainer(block))
+	if notation == "" {
+		return "-"
+	}
+	return notation
+}
+
+func parseBlockNotation(notation string, columns int) ([][]BoardElement, error) {
+	if notation == "-" {
+		return createContainer(blockSize), nil
+	}
+	block, err := parseContainerNotation(notation, columns)
+	if err != nil {
+		return nil, err
+	}
+	return squareContainer(block), nil
+}
+
+func containerNotation(container [][]BoardElement) string {
+	rows := make([]string, len(container))
+	for x, row := range container {
+		empty := 0
+		for _, element := range row {
+			if element == None {
+				empty++
+				continue
+			}
+			if empty > 0 {
+				rows[x] += strconv.Itoa(empty)
+				empty = 0
+			}
+			rows[x] += elementSymbol(element)
+		}
+		if empty > 0 {
+			rows[x] += strconv.Itoa(empty)
+		}
+	}
+	return strings.Join(rows, "/")
+}
+
+func elementSymbol(element BoardElement) string {
+	if element < 0 || int(element) >= len(elementSymbols) {
+		return "?"
+	}
+	return string(elementSymbols[element])
+}
+
+// parseContainerNotation returns container described by the notation, rows
+// longer than a given number of columns are rejected
+func parseContainerNotation(notation string, columns int) ([][]BoardElement, error) {
+	var container [][]BoardElement
+	for _, rowNotation := range strings.Split(notation, "/") {
+		var row []BoardElement
+		var err error
+		empty := ""
+		for _, character := range rowNotation {
+			if character >= '0' && character <= '9' {
+				empty += string(character)
+				continue
+			}
+			row, err = appendEmpty(row, empty, columns)
+			if err != nil {
+				return nil, err
+			}
+			empty = ""
+			element := strings.IndexRune(elementSymbols, character)
+			if element <= 0 {
+				return nil, fmt.Errorf("Unknown board element %q", character)
+			}
+			if len(row) >= columns {
+				return nil, fmt.Errorf("Row %q is longer than %d cells", rowNotation, columns)
+			}
+			row = append(row, BoardElement(element))
+		}
+		row, err = appendEmpty(row, empty, columns)
+		if err != nil {
+			return nil, err
+		}
+		container = append(container, row)
+	}
+	return container, nil
+}
+
+// appendEmpty adds a run of empty cells to the row, the run cannot make the
+// row longer than a given number of columns
+func appendEmpty(row []BoardElement, count string, columns int) ([]BoardElement, error) {
+	if count == "" {
+		return row, nil
+	}
+	empty, err := strconv.Atoi(count)
+	if err != nil || empty > columns-len(row) {
+		return nil, fmt.Errorf("Run of %s empty cells is longer than %d cells", count, columns)
+	}
+	for i := 0; i < empty; i++ {
+		row = append(row, None)
+	}
+	return row, nil
+}
+
+// trimmedContainer returns container without empty rows at the bottom and
+// empty cells at the end of each row
+func trimmedContainer(container [][]BoardElement) [][]BoardElement {
+	var trimmed [][]BoardElement
+	for x := range container {
+		length := 0
+		for y := range container[x] {
+			if container[x][y] != None {
+				length = y + 1
+			}
+		}
+		trimmed = append(trimmed, container[x][:length])
+	}
+	for len(trimmed) > 0 && len(trimmed[len(trimmed)-1]) == 0 {
+		trimmed = trimmed[:len(trimmed)-1]
+	}
+	return trimmed
+}
+
+// squareContainer places provided rows in the top left corner of a square
+// container, at least 5x5 size
+func squareContainer(rows [][]BoardElement) [][]BoardElement {
+	size := blockSize
+	if len(rows) > size {
+		size = len(rows)
+	}
+	for _, row := range rows {
+		if len(row) > size {
+			size = len(row)
+		}
+	}
+	container := createContainer(size)
+	for x, row := range rows {
+		copy(container[x], row)
+	}
+	return container
+}
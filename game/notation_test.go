@@ -0,0 +1,98 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotation(t *testing.T) {
+	assert := assert.New(t)
+
+	g := New()
+	g.Blocks[A] = blockShape(15)
+	g.Blocks[B] = createContainer(5)
+	g.Blocks[C] = blockShape(13)
+	g.Board[2][0] = Red
+	g.Board[2][1] = Red
+	g.Board[9][9] = Blue
+	g.Score = 4
+
+	assert.Equal("10/10/rr8/10/10/10/10/10/10/9b ww/w,-,2c/2c/ccc 4 p 1:3", g.Notation())
+
+	g.GameOver = true
+	assert.Equal("10/10/rr8/10/10/10/10/10/10/9b ww/w,-,2c/2c/ccc 4 o 1:3", g.Notation())
+
+	g.streak = 2
+	assert.Equal("10/10/rr8/10/10/10/10/10/10/9b ww/w,-,2c/2c/ccc 4 o 1:3 2", g.Notation())
+	parsed, err := ParseNotation(g.Notation())
+	assert.Nil(err)
+	assert.Equal(2, parsed.Streak())
+}
+
+func TestParseNotation(t *testing.T) {
+	assert := assert.New(t)
+
+	g := NewWithSeed(4)
+	for i := 0; i < 5; i++ {
+		moves := g.LegalMoves()
+		g.Move(moves[len(moves)/2].Block, moves[len(moves)/2].X, moves[len(moves)/2].Y)
+	}
+
+	parsed, err := ParseNotation(g.Notation())
+	assert.Nil(err)
+	assert.Equal(g.Notation(), parsed.Notation())
+	assert.Equal(g.Board, parsed.Board)
+	assert.Equal(g.Blocks, parsed.Blocks)
+	assert.Equal(g.Score, parsed.Score)
+
+	for i := 0; i < 5; i++ {
+		g.assignRandomBlocks()
+		parsed.assignRandomBlocks()
+		assert.Equal(g.Blocks, parsed.Blocks)
+	}
+
+	parsed, err = ParseNotation("3/r1g ggg/g,- 0 o 5:0", WithHistory())
+	assert.Nil(err)
+	assert.Equal(Config{Rows: 2, Columns: 3, Blocks: 2}, parsed.Config())
+	assert.Equal([][]BoardElement{{None, None, None}, {Red, None, Green}}, parsed.Board)
+	assert.True(parsed.GameOver)
+	assert.True(parsed.HistoryEnabled())
+	assert.Equal(int64(5), parsed.Seed())
+
+	// boards wider than the default one need the config
+	_, err = ParseNotation("12/12 - 0 p 1:0")
+	assert.NotNil(err)
+	config := DefaultConfig()
+	config.Columns = 12
+	parsed, err = ParseNotation("12/12 - 0 p 1:0", WithConfig(config))
+	assert.Nil(err)
+	assert.Equal(12, parsed.Config().Columns)
+}
+
+func TestParseNotationErrors(t *testing.T) {
+	assert := assert.New(t)
+
+	cases := []string{
+		"",
+		"10/10 - 0 p",
+		"3/2 - 0 p 1:0",
+		"3/3x - 0 p 1:0",
+		"3/3 x 0 p 1:0",
+		"3/3 - score p 1:0",
+		"3/3 - 0 q 1:0",
+		"3/3 - 0 p 1",
+		"3/3 - 0 p a:0",
+		"3/3 - 0 p 1:-1",
+		"99999999999 - 0 p 1:0",
+		"99999999999999999999 - 0 p 1:0",
+		"11/11 - 0 p 1:0",
+		"10/rrrrrrrrrrr - 0 p 1:0",
+		"3/3 rrrrrrrrrrr 0 p 1:0",
+	}
+
+	for _, c := range cases {
+		_, err := ParseNotation(c)
+		assert.NotNil(err, "%q must not be parsed", c)
+	}
+}
@@ -0,0 +1,164 @@
+package game
+
+// EventType represents kind of the change reported to observers
+type EventType int
+
+// EventType can be one of the following values
+const (
+	// BlockPlacedEvent is sent when block is placed on the board
+	BlockPlacedEvent EventType = 1
+	// LinesClearedEvent is sent when full rows or columns are removed
+	LinesClearedEvent EventType = 2
+	// BlocksDealtEvent is sent when new blocks are dealt after the last
+	// block was used
+	BlocksDealtEvent EventType = 3
+	// ScoreChangedEvent is sent when the score changes, including penalty
+	// for invalid move
+	ScoreChangedEvent EventType = 4
+	// GameOverEvent is sent when the game ends
+	GameOverEvent EventType = 5
+)
+
+var eventTypeNames = []string{"", "block placed", "lines cleared", "blocks dealt", "score changed", "game over"}
+
+// String returns name of the event type
+func (eventType EventType) String() string {
+	if eventType <= 0 || int(eventType) >= len(eventTypeNames) {
+		return "unknown event"
+	}
+	return eventTypeNames[eventType]
+}
+
+// Event describes single change of the game. Fields not related to the event
+// type are left empty.
+type Event struct {
+	Type EventType
+	// Move is the move which caused the event
+	Move Move
+	// CellsPlaced is set for BlockPlacedEvent
+	CellsPlaced int
+	// RowsCleared and ColumnsCleared are set for LinesClearedEvent
+	RowsCleared    []int
+	ColumnsCleared []int
+	// Blocks are new blocks, set for BlocksDealtEvent
+	Blocks [][][]BoardElement
+	// Score is the score after the event and ScoreDelta its change, set for
+	// ScoreChangedEvent and GameOverEvent
+	Score      int
+	ScoreDelta int
+	// Breakdown is set for ScoreChangedEvent sent after the move
+	Breakdown ScoreBreakdown
+}
+
+// Observer receives events of the game it was added to with Observe or
+// WithObserver. Events are delivered synchronously during Move, in order of
+// their occurrence: block placed, lines cleared, score changed, blocks dealt
+// and game over.
+type Observer interface {
+	Notify(event Event)
+}
+
+// ObserverFunc allows to use a function as an Observer
+type ObserverFunc func(event Event)
+
+// Notify calls the function
+func (f ObserverFunc) Notify(event Event) {
+	f(event)
+}
+
+// ChannelObserver returns Observer sending all events to the channel. Sending
+// blocks the game until the event is received unless the channel is buffered.
+func ChannelObserver(events chan<- Event) Observer {
+	return ObserverFunc(func(event Event) {
+		events <- event
+	})
+}
+
+// WithObserver adds observer receiving events of the game
+func WithObserver(observer Observer) Option {
+	return func(s *settings) {
+		s.observers = append(s.observers, observer)
+	}
+}
+
+// Observe adds observer receiving events of the game. Observers are not
+// copied by Clone so simulated moves are never reported. Undo and Redo do
+// not send events.
+func (g *Game) Observe(observer Observer) {
+	observers := make([]Observer, len(g.observers), len(g.observers)+1)
+	copy(observers, g.observers)
+	g.observers = append(observers, observer)
+}
+
+// notify sends the event to all observers
+func (g *Game) notify(event Event) {
+	for _, observer := range g.observers {
+		observer.Notify(event)
+	}
+}
+
+// notifyMove sends events describing the move which was made
+func (g *Game) notifyMove(result MoveResult) {
+	if len(g.observers) == 0 {
+		return
+	}
+
+	if result.Move.Action == PlaceBlock {
+		g.notify(Event{Type: BlockPlacedEvent, Move: result.Move, CellsPlaced: result.CellsPlaced})
+	}
+	if result.LinesCleared() > 0 {
+		g.notify(Event{Type: LinesClearedEvent, Move: result.Move, RowsCleared: result.RowsCleared, ColumnsCleared: result.ColumnsCleared})
+	}
+	if result.ScoreDelta != 0 {
+		g.notify(Event{Type: ScoreChangedEvent, Move: result.Move, Score: g.Score, ScoreDelta: result.ScoreDelta, Breakdown: result.Breakdown})
+	}
+	if result.BlocksDealt {
+		blocks := make([][][]BoardElement, len(g.Blocks))
+		for i := range g.Blocks {
+			blocks[i] = cloneContainer(g.Blocks[i])
+		}
+		g.notify(Event{Type: BlocksDealtEvent, Move: result.Move, Blocks: blocks})
+	}
+	if result.GameOver {
+		g.notify(Event{Type: GameOverEvent, Move: result.Move, Score: g.Score})
+	}
+}
+
+// Stats is an Observer counting events of the game, eg. to build fitness
+// function richer than the score
+type Stats struct {
+	// Moves is the number of blocks placed
+	Moves int
+	// CellsPlaced is the number of cells of all placed blocks
+	CellsPlaced int
+	// RowsCleared and ColumnsCleared are numbers of removed lanes
+	RowsCleared    int
+	ColumnsCleared int
+	// Combos is the number of moves which removed more than one lane and
+	// BestCombo the highest number of lanes removed by a single move
+	Combos    int
+	BestCombo int
+	// Deals is the number of times new blocks were dealt
+	Deals int
+}
+
+// Notify updates the counters
+func (stats *Stats) Notify(event Event) {
+	switch event.Type {
+	case BlockPlacedEvent:
+		stats.Moves++
+		stats.CellsPlaced += event.CellsPlaced
+	case LinesClearedEvent:
+		lines := len(event.RowsCleared) + len(event.ColumnsCleared)
+		stats.RowsCleared += len(event.RowsCleared)
+		stats.ColumnsCleared += len(event.ColumnsCleared)
+		if lines > 1 {
+			stats.Combos++
+		}
+		if lines > stats.BestCombo {
+			stats.BestCombo = lines
+		}
+	case BlocksDealtEvent:
+		stats.Deals++
+	}
+}
@@ -0,0 +1,108 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestObserverEvents(t *testing.T) {
+	assert := assert.New(t)
+
+	var events []Event
+	g := NewWithOptions(WithObserver(ObserverFunc(func(event Event) {
+		events = append(events, event)
+	})))
+	g.Blocks[A] = blockShape(7)
+	g.Blocks[B] = createContainer(5)
+	g.Blocks[C] = createContainer(5)
+	for y := 0; y < 5; y++ {
+		g.Board[0][y] = Red
+	}
+
+	assert.Nil(g.Move(A, 0, 5))
+	if !assert.Equal(4, len(events)) {
+		return
+	}
+	assert.Equal(Event{Type: BlockPlacedEvent, Move: Move{A, 0, 5, PlaceBlock}, CellsPlaced: 5}, events[0])
+	assert.Equal(Event{Type: LinesClearedEvent, Move: Move{A, 0, 5, PlaceBlock}, RowsCleared: []int{0}}, events[1])
+	assert.Equal(Event{Type: ScoreChangedEvent, Move: Move{A, 0, 5, PlaceBlock}, Score: 15, ScoreDelta: 15, Breakdown: ScoreBreakdown{Cells: 5, Lines: 10}}, events[2])
+	assert.Equal(BlocksDealtEvent, events[3].Type)
+	assert.Equal(g.Blocks, events[3].Blocks)
+}
+
+func TestObserverGameOver(t *testing.T) {
+	assert := assert.New(t)
+
+	events := make(chan Event, 10)
+	g := NewWithOptions(WithObserver(ChannelObserver(events)))
+	assert.Equal(IncorrectPosition, g.Move(A, -1, 0).(*ErrorGame).Reason)
+	assert.Equal(Event{Type: GameOverEvent, Move: Move{A, -1, 0, PlaceBlock}}, <-events)
+
+	config := DefaultConfig()
+	config.InvalidMove = PenalizeInvalidMove
+	config.InvalidMovePenalty = 3
+	g = NewWithOptions(WithConfig(config))
+	g.Observe(ChannelObserver(events))
+	g.Move(A, -1, 0)
+	assert.Equal(Event{Type: ScoreChangedEvent, Move: Move{A, -1, 0, PlaceBlock}, Score: -3, ScoreDelta: -3}, <-events)
+
+	g = New()
+	g.Observe(ChannelObserver(events))
+	for x := 0; x < 10; x++ {
+		for y := 0; y < 10; y++ {
+			if (x+y)%2 == 0 {
+				g.Board[x][y] = Green
+			}
+		}
+	}
+	g.Board[0][1] = None
+	g.Blocks[A] = blockShape(0)
+	g.Blocks[B] = blockShape(9)
+	g.Blocks[C] = createContainer(5)
+	assert.NotNil(g.Move(A, 0, 1))
+	assert.Equal(BlockPlacedEvent, (<-events).Type)
+	assert.Equal(ScoreChangedEvent, (<-events).Type)
+	assert.Equal(Event{Type: GameOverEvent, Move: Move{A, 0, 1, PlaceBlock}, Score: 1}, <-events)
+	assert.Equal(0, len(events))
+}
+
+func TestObserverNotCloned(t *testing.T) {
+	assert := assert.New(t)
+
+	notified := 0
+	g := NewWithOptions(WithHistory(), WithObserver(ObserverFunc(func(event Event) {
+		notified++
+	})))
+	moves := g.LegalMoves()
+
+	g.Simulate(moves[0])
+	clone := g.Clone()
+	clone.Move(moves[0].Block, moves[0].X, moves[0].Y)
+	assert.Equal(0, notified)
+
+	g.Move(moves[0].Block, moves[0].X, moves[0].Y)
+	assert.Equal(2, notified)
+	assert.Nil(g.Undo())
+	assert.Nil(g.Redo())
+	assert.Equal(2, notified)
+	moves = g.LegalMoves()
+	g.Move(moves[0].Block, moves[0].X, moves[0].Y)
+	assert.Equal(4, notified, "observers are kept after undo and redo")
+}
+
+func TestStats(t *testing.T) {
+	assert := assert.New(t)
+
+	stats := &Stats{}
+	g := NewWithOptions(WithObserver(stats))
+	for !g.GameOver {
+		moves := g.LegalMoves()
+		g.Move(moves[0].Block, moves[0].X, moves[0].Y)
+	}
+
+	assert.True(stats.Moves > 0)
+	assert.Equal(g.Score, stats.CellsPlaced+stats.RowsCleared*10+stats.ColumnsCleared*10)
+	assert.Equal(stats.Moves/3, stats.Deals)
+	assert.True(stats.BestCombo >= 1)
+}
@@ -0,0 +1,71 @@
+package game
+
+// Option configures Game created with NewWithOptions
+type Option func(*settings)
+
+// settings collects values set by options before the Game is created
+type settings struct {
+	seed      int64
+	generator BlockGenerator
+	scoring   ScoringRules
+	shapes    *Catalogue
+	config    Config
+	history   bool
+	observers []Observer
+	board     [][]BoardElement
+	clock     Clock
+}
+
+// validate checks if the settings describe a game which can be played
+func (s settings) validate() error {
+	if generator, ok := s.generator.(*ScriptedGenerator); ok {
+		return generator.Validate(s.shapes)
+	}
+	return nil
+}
+
+func defaultSettings() settings {
+	return settings{
+		seed:      DefaultSeed,
+		generator: UniformGenerator{},
+		shapes:    defaultCatalogue,
+		config:    DefaultConfig(),
+		clock:     SystemClock{},
+	}
+}
+
+// WithSeed sets seed of the pseudo random generator used to draw blocks
+func WithSeed(seed int64) Option {
+	return func(s *settings) {
+		s.seed = seed
+	}
+}
+
+// WithGenerator sets generator deciding which blocks are dealt
+func WithGenerator(generator BlockGenerator) Option {
+	return func(s *settings) {
+		s.generator = generator
+	}
+}
+
+// WithCatalogue sets catalogue of shapes which are dealt in the game
+func WithCatalogue(catalogue *Catalogue) Option {
+	return func(s *settings) {
+		s.shapes = catalogue
+	}
+}
+
+// WithConfig sets rules of the game
+func WithConfig(config Config) Option {
+	return func(s *settings) {
+		s.config = config
+	}
+}
+
+// WithBoard sets the starting board, eg. with stones or pre-filled cells.
+// Size of the board overrides Rows and Columns of the config.
+func WithBoard(board [][]BoardElement) Option {
+	return func(s *settings) {
+		s.board = cloneContainer(board)
+	}
+}
@@ -0,0 +1,280 @@
+package game
+
+import (
+	"math/bits"
+)
+
+// blockOffsets are filled cells of the block relative to its top left corner
+// together with size of the area they occupy, so that the placement can be
+// checked without scanning the whole block
+type blockOffsets struct {
+	cells   []Cell
+	rows    int
+	columns int
+}
+
+// offsetsOf returns offsets of filled cells of the block
+func offsetsOf(block [][]BoardElement) blockOffsets {
+	filled := 0
+	for _, row := range block {
+		for _, element := range row {
+			if element != None {
+				filled++
+			}
+		}
+	}
+	offsets := blockOffsets{cells: make([]Cell, 0, filled)}
+	for x := range block {
+		for y := range block[x] {
+			if block[x][y] == None {
+				continue
+			}
+			offsets.cells = append(offsets.cells, Cell{x, y})
+			if x+1 > offsets.rows {
+				offsets.rows = x + 1
+			}
+			if y+1 > offsets.columns {
+				offsets.columns = y + 1
+			}
+		}
+	}
+	return offsets
+}
+
+// fits checks if the block can be placed at x,y position of the board
+func (offsets blockOffsets) fits(board [][]BoardElement, x int, y int) bool {
+	if x < 0 || y < 0 || x+offsets.rows > len(board) || y+offsets.columns > len(board[0]) {
+		return false
+	}
+	for _, cell := range offsets.cells {
+		if board[x+cell.X][y+cell.Y] != None {
+			return false
+		}
+	}
+	return true
+}
+
+// same checks if both offsets describe the same cells
+func (offsets blockOffsets) same(other blockOffsets) bool {
+	if len(offsets.cells) != len(other.cells) {
+		return false
+	}
+	for i, cell := range offsets.cells {
+		if other.cells[i] != cell {
+			return false
+		}
+	}
+	return true
+}
+
+// placeableCache keeps positions where each playable block fits. The cache
+// remembers the board it was computed for and after a move only positions
+// covering changed cells, ie. cells of the placed block and removed lanes,
+// are checked again. New blocks are checked on the whole board once.
+type placeableCache struct {
+	// version is increased by every update, game changes the cache in place
+	// only when it knows the latest version, otherwise the cache is shared
+	// with a copy of the game and it is cloned first
+	version int
+	// board is a copy of the board known to the slice engine, bitboard
+	// engine remembers only filled cells
+	board   [][]BoardElement
+	filled  bitboard
+	entries []placeableEntry
+	// spare and changed are reused between updates to avoid allocations
+	spare   []placeableEntry
+	changed []Cell
+}
+
+// placeableEntry holds positions where the block fits, cell x,y being stored
+// at x*columns+y index
+type placeableEntry struct {
+	offsets   blockOffsets
+	positions []bool
+	count     int
+}
+
+// updatePlaceable brings the cache of the game up to date with the board and
+// blocks. Board modified directly, outside of moves, is handled in the same
+// way by the slice engine.
+func (g *Game) updatePlaceable(blocks [][][]BoardElement) *placeableCache {
+	cache := g.placeable
+	if cache == nil || cache.version != g.placeableVersion {
+		cache = cache.clone()
+		g.placeable = cache
+	}
+	cache.version++
+	g.placeableVersion = cache.version
+
+	if g.masks != nil {
+		state := g.boardState()
+		if cache.entries == nil && cache.spare == nil {
+			cache.update(g.Board, nil, true, blocks, g.shapes)
+		} else {
+			cache.update(g.Board, cache.changedBits(state.filled, g.masks.columns), false, blocks, g.shapes)
+		}
+		cache.filled = state.filled
+		return cache
+	}
+
+	if cache.board == nil || len(cache.board) != len(g.Board) || len(cache.board[0]) != len(g.Board[0]) {
+		cache.board = cloneContainer(g.Board)
+		cache.update(g.Board, nil, true, blocks, g.shapes)
+	} else {
+		cache.update(g.Board, cache.changedCells(g.Board), false, blocks, g.shapes)
+	}
+	return cache
+}
+
+// update refreshes entries of the blocks on changed cells, all entries are
+// computed again when reset is true
+func (cache *placeableCache) update(board [][]BoardElement, changed []Cell, reset bool, blocks [][][]BoardElement, shapes *Catalogue) {
+	unused := cache.entries
+	if reset {
+		unused = nil
+	}
+
+	entries := cache.spare[:0]
+	for _, block := range blocks {
+		offsets := shapes.offsetsOf(block)
+		if len(offsets.cells) == 0 || indexOfOffsets(entries, offsets) >= 0 {
+			continue
+		}
+		if i := indexOfOffsets(unused, offsets); i >= 0 {
+			entry := unused[i]
+			entry.refresh(board, changed)
+			entries = append(entries, entry)
+			// matched entry is moved to the front, positions of the
+			// remaining ones can be reused by new entries
+			unused[0], unused[i] = unused[i], unused[0]
+			unused = unused[1:]
+			continue
+		}
+		var positions []bool
+		if len(unused) > 0 {
+			positions = unused[len(unused)-1].positions
+			unused = unused[:len(unused)-1]
+		}
+		entries = append(entries, newPlaceableEntry(board, offsets, positions))
+	}
+	cache.entries, cache.spare = entries, cache.entries
+}
+
+// any checks if any of the blocks fits anywhere on the board
+func (cache *placeableCache) any() bool {
+	for _, entry := range cache.entries {
+		if entry.count > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// changedCells returns cells of the board which differ from the board known
+// to the cache and updates the known board
+func (cache *placeableCache) changedCells(board [][]BoardElement) []Cell {
+	changed := cache.changed[:0]
+	for x, row := range board {
+		known := cache.board[x]
+		for y, element := range row {
+			if known[y] != element {
+				changed = append(changed, Cell{x, y})
+				known[y] = element
+			}
+		}
+	}
+	cache.changed = changed
+	return changed
+}
+
+// changedBits returns cells which differ from the filled cells known to the
+// cache, x,y cell being stored in bit x*columns+y
+func (cache *placeableCache) changedBits(filled bitboard, columns int) []Cell {
+	changed := cache.changed[:0]
+	diff := bitboard{cache.filled.low ^ filled.low, cache.filled.high ^ filled.high}
+	for offset, word := range [2]uint64{diff.low, diff.high} {
+		for ; word != 0; word &= word - 1 {
+			index := offset*64 + bits.TrailingZeros64(word)
+			changed = append(changed, Cell{index / columns, index % columns})
+		}
+	}
+	cache.changed = changed
+	return changed
+}
+
+// clone returns cache which can be modified independently, empty cache is
+// returned for nil
+func (cache *placeableCache) clone() *placeableCache {
+	if cache == nil {
+		return &placeableCache{}
+	}
+	clone := &placeableCache{
+		filled:  cache.filled,
+		entries: make([]placeableEntry, len(cache.entries)),
+	}
+	if cache.board != nil {
+		clone.board = cloneContainer(cache.board)
+	}
+	for i, entry := range cache.entries {
+		entry.positions = append([]bool(nil), entry.positions...)
+		clone.entries[i] = entry
+	}
+	return clone
+}
+
+// newPlaceableEntry checks the block on all positions of the board, provided
+// positions of an entry which is no longer used are overwritten
+func newPlaceableEntry(board [][]BoardElement, offsets blockOffsets, positions []bool) placeableEntry {
+	columns := len(board[0])
+	if len(positions) != len(board)*columns {
+		positions = make([]bool, len(board)*columns)
+	} else {
+		for i := range positions {
+			positions[i] = false
+		}
+	}
+	entry := placeableEntry{offsets: offsets, positions: positions}
+	for x := 0; x+offsets.rows <= len(board); x++ {
+		for y := 0; y+offsets.columns <= columns; y++ {
+			if offsets.fits(board, x, y) {
+				entry.positions[x*columns+y] = true
+				entry.count++
+			}
+		}
+	}
+	return entry
+}
+
+// refresh checks again positions where the block covers any of the changed
+// cells, other positions cannot change
+func (entry *placeableEntry) refresh(board [][]BoardElement, changed []Cell) {
+	columns := len(board[0])
+	for _, cell := range changed {
+		for _, offset := range entry.offsets.cells {
+			x, y := cell.X-offset.X, cell.Y-offset.Y
+			if x < 0 || y < 0 || x+entry.offsets.rows > len(board) || y+entry.offsets.columns > columns {
+				continue
+			}
+			fits := entry.offsets.fits(board, x, y)
+			if fits != entry.positions[x*columns+y] {
+				entry.positions[x*columns+y] = fits
+				if fits {
+					entry.count++
+				} else {
+					entry.count--
+				}
+			}
+		}
+	}
+}
+
+// indexOfOffsets returns index of the entry of the block with given offsets,
+// -1 is returned when there is no such entry
+func indexOfOffsets(entries []placeableEntry, offsets blockOffsets) int {
+	for i, entry := range entries {
+		if entry.offsets.same(offsets) {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,93 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBlockOffsets(t *testing.T) {
+	assert := assert.New(t)
+
+	offsets := offsetsOf(blockShape(11))
+	assert.Equal([]Cell{{0, 0}, {0, 1}, {0, 2}, {1, 0}, {2, 0}}, offsets.cells)
+	assert.Equal(3, offsets.rows)
+	assert.Equal(3, offsets.columns)
+	assert.Equal(offsets, defaultCatalogue.offsetsOf(blockShape(11)), "offsets of shapes are precomputed")
+	assert.Equal(offsetsOf(blockShape(11)), defaultCatalogue.offsetsOf(append(blockShape(11), make([]BoardElement, 5))))
+
+	board := createBoard(10, 10)
+	board[1][1] = Red
+	assert.True(offsets.fits(board, 7, 7))
+	assert.False(offsets.fits(board, 8, 7))
+	assert.True(offsets.fits(board, 1, 2))
+	assert.False(offsets.fits(board, 0, 1))
+	assert.False(offsets.fits(board, 1, 1))
+}
+
+func TestPlaceable(t *testing.T) {
+	assert := assert.New(t)
+
+	// cached positions of every playable block are the same as positions
+	// found by checking the whole board
+	checkCache := func(g Game) {
+		cache := g.placeable
+		blocks := g.playableBlocks()
+		fits := false
+		for _, block := range blocks {
+			entry := cache.entries[indexOfOffsets(cache.entries, g.shapes.offsetsOf(block))]
+			count := 0
+			for x := range g.Board {
+				for y := range g.Board[x] {
+					possible := g.isMovePossible(block, x, y)
+					assert.Equal(possible, entry.positions[x*len(g.Board[x])+y], "position %d,%d", x, y)
+					if possible {
+						count++
+					}
+				}
+			}
+			assert.Equal(count, entry.count)
+			fits = fits || count > 0
+		}
+		assert.Equal(fits, cache.any())
+	}
+
+	for _, engine := range []Engine{SliceEngine, BitboardEngine} {
+		config := DefaultConfig()
+		config.Engine = engine
+		for seed := int64(0); seed < 20; seed++ {
+			g := NewWithOptions(WithSeed(seed), WithConfig(config), WithHistory())
+			for i := 0; !g.GameOver; i++ {
+				assert.False(g.isGameOver())
+				checkCache(g)
+
+				// cache shared with the copy is not changed by it
+				moves := g.LegalMoves()
+				preview, _, _ := g.Preview(moves[0])
+				preview.isGameOver()
+				checkCache(preview)
+				checkCache(g)
+
+				move := moves[(i*7)%len(moves)]
+				g.Move(move.Block, move.X, move.Y)
+
+				if i%5 == 4 && !g.GameOver {
+					// board modified outside of moves and restored by undo,
+					// bitboard engine notices only a new board
+					if engine == SliceEngine {
+						g.Board[move.X][move.Y] = Stone
+					} else {
+						g.Board = cloneContainer(g.Board)
+						g.Board[move.X][move.Y] = Stone
+					}
+					g.isGameOver()
+					checkCache(g)
+					assert.Nil(g.Undo())
+					g.isGameOver()
+					checkCache(g)
+				}
+			}
+			assert.False(g.placeable.any())
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package game
+
+import (
+	"math/rand"
+)
+
+// randomSource is a rand.Source counting generated values. It is a
+// SplitMix64 generator, its whole state is a single number which moves by
+// a constant step with every value, so the source is copied by copying the
+// state and a position is restored from the seed and number of values
+// without generating them.
+type randomSource struct {
+	seed  int64
+	draws uint64
+	state uint64
+}
+
+// splitMixGamma is the step of the SplitMix64 state
+const splitMixGamma = 0x9e3779b97f4a7c15
+
+func newRandomSource(seed int64) *randomSource {
+	return newRandomSourceAt(seed, 0)
+}
+
+// newRandomSourceAt returns source which already generated given number of values
+func newRandomSourceAt(seed int64, draws uint64) *randomSource {
+	return &randomSource{seed: seed, draws: draws, state: uint64(seed) + draws*splitMixGamma}
+}
+
+// Int63 returns next pseudo random value of the source
+func (random *randomSource) Int63() int64 {
+	return int64(random.Uint64() >> 1)
+}
+
+// Uint64 returns next pseudo random value of the source
+func (random *randomSource) Uint64() uint64 {
+	random.draws++
+	random.state += splitMixGamma
+	value := random.state
+	value = (value ^ (value >> 30)) * 0xbf58476d1ce4e5b9
+	value = (value ^ (value >> 27)) * 0x94d049bb133111eb
+	return value ^ (value >> 31)
+}
+
+// Seed resets the source to the beginning of sequence of a given seed
+func (random *randomSource) Seed(seed int64) {
+	*random = *newRandomSource(seed)
+}
+
+// clone returns source at the same position
+func (random *randomSource) clone() *randomSource {
+	clone := *random
+	return &clone
+}
+
+// drawsPerBlock is the number of random values which is always enough to
+// choose a shape with any of the built-in generators
+const drawsPerBlock = 16
+
+// maxDraws returns the number of random values which is enough to deal blocks
+// in a game with a given number of placed blocks. Bag generator draws values
+// for all shapes at once, so the number of shapes is added.
+func maxDraws(config Config, moves int, shapes int) uint64 {
+	deals := uint64(moves + config.Rerolls + 1)
+	return deals*uint64(config.Blocks)*drawsPerBlock + uint64(shapes)
+}
+
+// setRandomPosition restores random generator of the game to the position
+// after given number of draws from the sequence of a given seed
+func (g *Game) setRandomPosition(seed int64, draws uint64) {
+	g.seed = seed
+	g.random = newRandomSourceAt(seed, draws)
+	g.randomGenerator = rand.New(g.random)
+}
@@ -0,0 +1,39 @@
+package game
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRandomSource(t *testing.T) {
+	assert := assert.New(t)
+
+	random := newRandomSource(3)
+	generator := rand.New(random)
+
+	// values of the reference SplitMix64 implementation for seed 0
+	reference := rand.New(newRandomSource(0))
+	assert.Equal(uint64(0xe220a8397b1dcdaf), reference.Uint64())
+	assert.Equal(uint64(0x6e789e6aa1b965f4), reference.Uint64())
+
+	var values []int
+	for i := 0; i < 5; i++ {
+		values = append(values, generator.Intn(19))
+	}
+	assert.Equal(uint64(5), random.draws)
+
+	clone := rand.New(random.clone())
+	restored := rand.New(newRandomSourceAt(3, 5))
+	for i := 0; i < 5; i++ {
+		value := generator.Int63()
+		assert.Equal(value, clone.Int63())
+		assert.Equal(value, restored.Int63())
+	}
+
+	random.Seed(3)
+	for _, value := range values {
+		assert.Equal(value, generator.Intn(19))
+	}
+}
@@ -0,0 +1,147 @@
+package game
+
+// ScoringMode selects ScoringRules used by the game
+type ScoringMode int
+
+// ScoringMode can be one of the following values
+const (
+	// ClassicScoring gives a point for each placed cell and a point for each
+	// cell of removed lanes
+	ClassicScoring ScoringMode = 0
+	// ComboScoring extends ClassicScoring with bonuses of the original 1010!
+	// game for removing many lanes at once and in consecutive moves
+	ComboScoring ScoringMode = 1
+)
+
+// ScoringInput describes the move being scored
+type ScoringInput struct {
+	// CellsPlaced is the number of non empty cells of the placed block
+	CellsPlaced int
+	// RowsCleared and ColumnsCleared are numbers of removed lanes
+	RowsCleared    int
+	ColumnsCleared int
+	// Rows and Columns are size of the board
+	Rows    int
+	Columns int
+	// Streak is the number of consecutive moves which removed lanes, this
+	// move included. It is 0 when the move did not remove any lane.
+	Streak int
+}
+
+// ScoreBreakdown describes points received for the move
+type ScoreBreakdown struct {
+	// Cells are points for placed cells
+	Cells int `json:"cells"`
+	// Lines are points for removed lanes
+	Lines int `json:"lines"`
+	// Combo is the bonus for removing more than one lane with a single move
+	Combo int `json:"combo"`
+	// Streak is the bonus for removing lanes in consecutive moves
+	Streak int `json:"streak"`
+}
+
+// Total returns sum of all points
+func (breakdown ScoreBreakdown) Total() int {
+	return breakdown.Cells + breakdown.Lines + breakdown.Combo + breakdown.Streak
+}
+
+// ScoringRules decide how many points are received for the move
+type ScoringRules interface {
+	Score(input ScoringInput) ScoreBreakdown
+}
+
+// ClassicRules is scoring used by go1010 from the beginning: a point for
+// each placed cell and a point for each cell of removed lanes
+type ClassicRules struct{}
+
+// Score returns points for the move
+func (ClassicRules) Score(input ScoringInput) ScoreBreakdown {
+	return ScoreBreakdown{
+		Cells: input.CellsPlaced,
+		Lines: input.RowsCleared*input.Columns + input.ColumnsCleared*input.Rows,
+	}
+}
+
+// comboBonus is the bonus for each lane removed together with the previous
+// ones: 2 lanes give 10 extra points, 3 lanes 30, 4 lanes 60 and so on
+const comboBonus = 10
+
+// maxStreakMultiplier limits the multiplier of points for lanes removed in
+// consecutive moves
+const maxStreakMultiplier = 3
+
+// ComboRules is scoring of the original 1010! game. Points for lanes grow
+// with the number of lanes removed at once (10, 30, 60, 100... on 10x10
+// board) and are multiplied by 1.5, 2, 2.5 up to 3 when lanes are removed in
+// consecutive moves.
+type ComboRules struct{}
+
+// Score returns points for the move
+func (ComboRules) Score(input ScoringInput) ScoreBreakdown {
+	breakdown := ClassicRules{}.Score(input)
+
+	lines := input.RowsCleared + input.ColumnsCleared
+	breakdown.Combo = comboBonus * lines * (lines - 1) / 2
+
+	if input.Streak > 1 {
+		// streak multiplier grows by 0.5 with each move, kept in halves
+		// to stay in integers
+		halves := input.Streak + 1
+		if halves > 2*maxStreakMultiplier {
+			halves = 2 * maxStreakMultiplier
+		}
+		breakdown.Streak = (breakdown.Lines + breakdown.Combo) * (halves - 2) / 2
+	}
+	return breakdown
+}
+
+// scoringRules returns rules selected by the config
+func (config Config) scoringRules() ScoringRules {
+	if config.Scoring == ComboScoring {
+		return ComboRules{}
+	}
+	return ClassicRules{}
+}
+
+// WithScoring sets rules deciding how many points are received for a move,
+// they replace rules selected by Scoring of the config. Only ClassicRules and
+// ComboRules can be encoded to JSON.
+func WithScoring(rules ScoringRules) Option {
+	return func(s *settings) {
+		s.scoring = rules
+	}
+}
+
+// ScoringRules returns rules deciding how many points are received for a move
+func (g Game) ScoringRules() ScoringRules {
+	if g.scoring != nil {
+		return g.scoring
+	}
+	return g.config.scoringRules()
+}
+
+// Streak returns the number of consecutive moves, up to the last one, which
+// removed lanes
+func (g Game) Streak() int {
+	return g.streak
+}
+
+// score adds points for the move to the score and updates the streak
+func (g *Game) score(result *MoveResult) {
+	if result.LinesCleared() > 0 {
+		g.streak++
+	} else {
+		g.streak = 0
+	}
+
+	result.Streak = g.streak
+	result.Breakdown = g.ScoringRules().Score(ScoringInput{
+		CellsPlaced:    result.CellsPlaced,
+		RowsCleared:    len(result.RowsCleared),
+		ColumnsCleared: len(result.ColumnsCleared),
+		Rows:           len(g.Board),
+		Columns:        len(g.Board[0]),
+		Streak:         g.streak,
+	})
+	g.Score += result.Breakdown.Total()
+}
@@ -0,0 +1,132 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassicRules(t *testing.T) {
+	assert := assert.New(t)
+
+	breakdown := ClassicRules{}.Score(ScoringInput{CellsPlaced: 5, RowsCleared: 1, ColumnsCleared: 2, Rows: 8, Columns: 10, Streak: 4})
+	assert.Equal(ScoreBreakdown{Cells: 5, Lines: 26}, breakdown)
+	assert.Equal(31, breakdown.Total())
+}
+
+func TestComboRules(t *testing.T) {
+	assert := assert.New(t)
+
+	testCases := []struct {
+		input    ScoringInput
+		expected ScoreBreakdown
+	}{
+		{ScoringInput{CellsPlaced: 3, Rows: 10, Columns: 10}, ScoreBreakdown{Cells: 3}},
+		{ScoringInput{CellsPlaced: 3, RowsCleared: 1, Rows: 10, Columns: 10, Streak: 1}, ScoreBreakdown{Cells: 3, Lines: 10}},
+		{ScoringInput{CellsPlaced: 5, RowsCleared: 1, ColumnsCleared: 1, Rows: 10, Columns: 10, Streak: 1}, ScoreBreakdown{Cells: 5, Lines: 20, Combo: 10}},
+		{ScoringInput{CellsPlaced: 9, RowsCleared: 3, ColumnsCleared: 1, Rows: 10, Columns: 10, Streak: 1}, ScoreBreakdown{Cells: 9, Lines: 40, Combo: 60}},
+		{ScoringInput{CellsPlaced: 1, RowsCleared: 1, Rows: 10, Columns: 10, Streak: 2}, ScoreBreakdown{Cells: 1, Lines: 10, Streak: 5}},
+		{ScoringInput{CellsPlaced: 1, RowsCleared: 2, Rows: 10, Columns: 10, Streak: 3}, ScoreBreakdown{Cells: 1, Lines: 20, Combo: 10, Streak: 30}},
+		{ScoringInput{CellsPlaced: 1, RowsCleared: 1, Rows: 10, Columns: 10, Streak: 5}, ScoreBreakdown{Cells: 1, Lines: 10, Streak: 20}},
+		{ScoringInput{CellsPlaced: 1, RowsCleared: 1, Rows: 10, Columns: 10, Streak: 9}, ScoreBreakdown{Cells: 1, Lines: 10, Streak: 20}},
+	}
+
+	for _, testCase := range testCases {
+		assert.Equal(testCase.expected, ComboRules{}.Score(testCase.input), "%+v", testCase.input)
+	}
+}
+
+func TestComboScoringGame(t *testing.T) {
+	assert := assert.New(t)
+
+	config := DefaultConfig()
+	config.Scoring = ComboScoring
+	for _, engine := range []Engine{SliceEngine, BitboardEngine} {
+		config.Engine = engine
+		g := NewWithOptions(WithConfig(config))
+		g.Blocks[A] = blockShape(0)
+		g.Blocks[B] = blockShape(0)
+		g.Blocks[C] = blockShape(0)
+		for y := 1; y < 10; y++ {
+			g.Board[0][y] = Red
+		}
+		for x := 1; x < 10; x++ {
+			g.Board[x][0] = Red
+		}
+
+		result, err := g.play(Move{A, 0, 0, PlaceBlock})
+		assert.Nil(err)
+		assert.Equal(ScoreBreakdown{Cells: 1, Lines: 20, Combo: 10}, result.Breakdown)
+		assert.Equal(31, result.ScoreDelta)
+		assert.Equal(1, result.Streak)
+
+		// bitboard engine notices only a new board assigned between moves
+		board := cloneContainer(g.Board)
+		for y := 1; y < 10; y++ {
+			board[5][y] = Red
+		}
+		g.Board = board
+		result, _ = g.play(Move{B, 5, 0, PlaceBlock})
+		assert.Equal(ScoreBreakdown{Cells: 1, Lines: 10, Streak: 5}, result.Breakdown)
+		assert.Equal(2, g.Streak())
+
+		result, _ = g.play(Move{C, 5, 5, PlaceBlock})
+		assert.Equal(ScoreBreakdown{Cells: 1}, result.Breakdown)
+		assert.Equal(0, g.Streak())
+		assert.Equal(48, g.Score)
+	}
+}
+
+func TestScoringModeSerialized(t *testing.T) {
+	assert := assert.New(t)
+
+	config := DefaultConfig()
+	config.Scoring = ComboScoring
+	g := NewWithOptions(WithConfig(config))
+	g.streak = 3
+
+	data, err := g.MarshalJSON()
+	assert.Nil(err)
+	var decoded Game
+	assert.Nil(decoded.UnmarshalJSON(data))
+	assert.Equal(ComboScoring, decoded.Config().Scoring)
+	assert.Equal(3, decoded.Streak())
+
+	config.Scoring = ScoringMode(7)
+	assert.Equal(ClassicScoring, config.normalized().Scoring)
+}
+
+// cellsOnlyRules gives points only for placed cells
+type cellsOnlyRules struct{}
+
+func (cellsOnlyRules) Score(input ScoringInput) ScoreBreakdown {
+	return ScoreBreakdown{Cells: input.CellsPlaced}
+}
+
+func TestWithScoring(t *testing.T) {
+	assert := assert.New(t)
+
+	g := NewWithOptions(WithScoring(cellsOnlyRules{}))
+	assert.Equal(cellsOnlyRules{}, g.ScoringRules())
+	g.Blocks[A] = blockShape(0)
+	for y := 1; y < 10; y++ {
+		g.Board[0][y] = Red
+	}
+	result, err := g.play(Move{A, 0, 0, PlaceBlock})
+	assert.Nil(err)
+	assert.Equal(ScoreBreakdown{Cells: 1}, result.Breakdown)
+	assert.Equal(1, g.Score)
+	assert.Equal(cellsOnlyRules{}, g.Clone().ScoringRules())
+
+	_, err = g.MarshalJSON()
+	assert.NotNil(err)
+
+	// built-in rules are encoded as the scoring mode of the config
+	g = NewWithOptions(WithScoring(ComboRules{}))
+	data, err := g.MarshalJSON()
+	assert.Nil(err)
+	var decoded Game
+	assert.Nil(decoded.UnmarshalJSON(data))
+	assert.Equal(ComboRules{}, decoded.ScoringRules())
+	assert.Equal(ClassicRules{}, New().ScoringRules())
+}
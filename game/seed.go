@@ -0,0 +1,51 @@
+package game
+
+import (
+	"math/rand"
+	"time"
+)
+
+// SeedProvider decides which seed is used for a game played by a given index
+// of the population in a given generation
+type SeedProvider interface {
+	Seed(generation int, index int) int64
+}
+
+// SameSeed provides the same seed for every game in every generation
+type SameSeed int64
+
+// Seed returns the constant seed
+func (s SameSeed) Seed(generation int, index int) int64 {
+	return int64(s)
+}
+
+// GenerationSeed provides a fresh seed for each generation which is shared
+// by all games within the generation. Seeds are derived from the base value
+// so that the whole run can be reproduced.
+type GenerationSeed int64
+
+// NewTimeGenerationSeed returns GenerationSeed with base value taken from
+// current time in nanoseconds
+func NewTimeGenerationSeed() GenerationSeed {
+	return GenerationSeed(time.Now().UnixNano())
+}
+
+// Seed returns the seed of the generation, index is ignored
+func (s GenerationSeed) Seed(generation int, index int) int64 {
+	return rand.New(rand.NewSource(int64(s) + int64(generation))).Int63()
+}
+
+// BenchmarkSeeds provides seeds from a fixed set, game at index i plays with
+// seed i modulo length of the set regardless of the generation
+type BenchmarkSeeds []int64
+
+// Seed returns seed from the set for a given index
+func (s BenchmarkSeeds) Seed(generation int, index int) int64 {
+	if len(s) == 0 {
+		return DefaultSeed
+	}
+	if index < 0 {
+		index = -index
+	}
+	return s[index%len(s)]
+}
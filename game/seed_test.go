@@ -0,0 +1,37 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSameSeed(t *testing.T) {
+	assert := assert.New(t)
+
+	provider := SameSeed(5)
+
+	assert.Equal(int64(5), provider.Seed(0, 0))
+	assert.Equal(int64(5), provider.Seed(10, 499))
+}
+
+func TestGenerationSeed(t *testing.T) {
+	assert := assert.New(t)
+
+	provider := GenerationSeed(5)
+
+	assert.Equal(provider.Seed(0, 0), provider.Seed(0, 499))
+	assert.Equal(provider.Seed(3, 1), GenerationSeed(5).Seed(3, 2))
+	assert.NotEqual(provider.Seed(0, 0), provider.Seed(1, 0))
+}
+
+func TestBenchmarkSeeds(t *testing.T) {
+	assert := assert.New(t)
+
+	provider := BenchmarkSeeds{11, 12, 13}
+
+	assert.Equal(int64(11), provider.Seed(0, 0))
+	assert.Equal(int64(12), provider.Seed(7, 1))
+	assert.Equal(int64(11), provider.Seed(7, 3))
+	assert.Equal(DefaultSeed, BenchmarkSeeds{}.Seed(0, 4))
+}
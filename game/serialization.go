@@ -0,0 +1,237 @@
+package game
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// gameJSON is the format of the game encoded by MarshalJSON. Board and blocks
+// are stored as rows of text using the same symbols as Notation, '.' being
+// an empty cell. Shapes are stored only when they differ from the default
+// catalogue.
+type gameJSON struct {
+	Config    Config        `json:"config"`
+	Board     []string      `json:"board"`
+	Blocks    [][]string    `json:"blocks"`
+	Held      []string      `json:"held,omitempty"`
+	Score     int           `json:"score"`
+	Streak    int           `json:"streak,omitempty"`
+	Rerolls   int           `json:"rerolls,omitempty"`
+	Moves     int           `json:"moves,omitempty"`
+	Elapsed   time.Duration `json:"elapsed,omitempty"`
+	GameOver  bool          `json:"gameOver"`
+	Seed      int64         `json:"seed"`
+	Draws     uint64        `json:"draws"`
+	Generator generatorJSON `json:"generator"`
+	Shapes    *Catalogue    `json:"shapes,omitempty"`
+}
+
+// generatorJSON describes one of the built-in generators and its state
+type generatorJSON struct {
+	Type     string    `json:"type"`
+	Weights  []float64 `json:"weights,omitempty"`
+	Bag      []int     `json:"bag,omitempty"`
+	Sequence []int     `json:"sequence,omitempty"`
+	Position int       `json:"position,omitempty"`
+}
+
+// MarshalJSON encodes the game including position of the random generator
+// and state of the block generator, so that the game continues the same way
+// after decoding. Only built-in block generators are supported. History of
+// moves is not encoded. Time limit continues from the time already used.
+func (g Game) MarshalJSON() ([]byte, error) {
+	generator, err := marshalGenerator(g.generator)
+	if err != nil {
+		return nil, err
+	}
+
+	config := g.config
+	switch g.scoring.(type) {
+	case nil:
+	case ClassicRules:
+		config.Scoring = ClassicScoring
+	case ComboRules:
+		config.Scoring = ComboScoring
+	default:
+		return nil, fmt.Errorf("Scoring rules of type %T cannot be encoded", g.scoring)
+	}
+
+	description := gameJSON{
+		Config:    config,
+		Board:     rowsText(g.Board),
+		Blocks:    make([][]string, len(g.Blocks)),
+		Score:     g.Score,
+		Streak:    g.streak,
+		Rerolls:   g.rerolls,
+		Moves:     g.moves,
+		GameOver:  g.GameOver,
+		Seed:      g.seed,
+		Generator: generator,
+	}
+	for i, block := range g.Blocks {
+		description.Blocks[i] = rowsText(trimmedContainer(block))
+	}
+	if g.Held != nil {
+		description.Held = rowsText(trimmedContainer(g.Held))
+	}
+	if g.config.TimeLimit > 0 {
+		description.Elapsed = g.Elapsed()
+	}
+	if g.random != nil {
+		description.Draws = g.random.draws
+	}
+	if g.shapes != nil && g.shapes != defaultCatalogue {
+		description.Shapes = g.shapes
+	}
+
+	return json.Marshal(description)
+}
+
+// UnmarshalJSON decodes the game encoded by MarshalJSON
+func (g *Game) UnmarshalJSON(data []byte) error {
+	var description gameJSON
+	if err := json.Unmarshal(data, &description); err != nil {
+		return err
+	}
+
+	board, err := parseRowsText(description.Board)
+	if err != nil {
+		return err
+	}
+	if len(board) == 0 || len(board) != description.Config.Rows || len(board[0]) != description.Config.Columns {
+		return fmt.Errorf("Board size does not match config %dx%d", description.Config.Rows, description.Config.Columns)
+	}
+	for _, row := range board {
+		if len(row) != description.Config.Columns {
+			return fmt.Errorf("All rows of the board must have the same length")
+		}
+	}
+	if len(description.Blocks) != description.Config.Blocks {
+		return fmt.Errorf("Number of blocks does not match config %d", description.Config.Blocks)
+	}
+
+	settings := defaultSettings()
+	settings.config = description.Config
+	settings.seed = description.Seed
+	settings.generator, err = description.Generator.generator()
+	if err != nil {
+		return err
+	}
+	if description.Shapes != nil {
+		if len(description.Shapes.Shapes) == 0 {
+			return fmt.Errorf("Catalogue %q has no shapes", description.Shapes.Name)
+		}
+		settings.shapes = NewCatalogue(description.Shapes.Name, description.Shapes.Shapes...)
+	}
+
+	if err := settings.validate(); err != nil {
+		return err
+	}
+
+	decoded := newGame(settings)
+	if description.Moves < 0 {
+		return fmt.Errorf("Incorrect number of moves %d", description.Moves)
+	}
+	if limit := maxDraws(decoded.config, description.Moves, len(decoded.shapes.Shapes)); description.Draws > limit {
+		return fmt.Errorf("Random generator position %d is past %d values used by %d moves", description.Draws, limit, description.Moves)
+	}
+	decoded.Board = board
+	for i, rows := range description.Blocks {
+		block, err := parseRowsText(rows)
+		if err != nil {
+			return err
+		}
+		decoded.Blocks[i] = squareContainer(block)
+	}
+	if decoded.Held != nil {
+		held, err := parseRowsText(description.Held)
+		if err != nil {
+			return err
+		}
+		decoded.Held = squareContainer(held)
+	}
+	decoded.Score = description.Score
+	decoded.streak = description.Streak
+	decoded.rerolls = description.Rerolls
+	decoded.moves = description.Moves
+	decoded.started = decoded.started.Add(-description.Elapsed)
+	decoded.GameOver = description.GameOver
+	decoded.setRandomPosition(description.Seed, description.Draws)
+
+	*g = decoded
+	return nil
+}
+
+func marshalGenerator(generator BlockGenerator) (generatorJSON, error) {
+	switch generator := generator.(type) {
+	case nil, UniformGenerator:
+		return generatorJSON{Type: "uniform"}, nil
+	case WeightedGenerator:
+		return generatorJSON{Type: "weighted", Weights: generator.Weights}, nil
+	case *BagGenerator:
+		return generatorJSON{Type: "bag", Bag: generator.bag}, nil
+	case *ScriptedGenerator:
+		return generatorJSON{Type: "scripted", Sequence: generator.sequence, Position: generator.position}, nil
+	}
+	return generatorJSON{}, fmt.Errorf("Generator of type %T cannot be encoded", generator)
+}
+
+func (description generatorJSON) generator() (BlockGenerator, error) {
+	switch description.Type {
+	case "", "uniform":
+		return UniformGenerator{}, nil
+	case "weighted":
+		return WeightedGenerator{Weights: description.Weights}, nil
+	case "bag":
+		return &BagGenerator{bag: description.Bag}, nil
+	case "scripted":
+		return &ScriptedGenerator{sequence: description.Sequence, position: description.Position}, nil
+	}
+	return nil, fmt.Errorf("Unknown generator type %q", description.Type)
+}
+
+// rowsText returns each row of the container as text, one symbol per cell
+func rowsText(container [][]BoardElement) []string {
+	rows := make([]string, len(container))
+	for x, row := range container {
+		for _, element := range row {
+			rows[x] += elementSymbol(element)
+		}
+	}
+	return rows
+}
+
+// ParseRows parses board described by rows of text using symbols of the
+// notation, '.' being an empty cell and 's' a stone, eg. {"s..r", "..rr"}.
+// Rows must have the same length.
+func ParseRows(rows []string) ([][]BoardElement, error) {
+	board, err := parseRowsText(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(board) == 0 || len(board[0]) == 0 {
+		return nil, fmt.Errorf("Board must not be empty")
+	}
+	for _, row := range board {
+		if len(row) != len(board[0]) {
+			return nil, fmt.Errorf("All rows of the board must have the same length")
+		}
+	}
+	return board, nil
+}
+
+func parseRowsText(rows []string) ([][]BoardElement, error) {
+	container := make([][]BoardElement, len(rows))
+	for x, row := range rows {
+		for _, character := range row {
+			element := strings.IndexRune(elementSymbols, character)
+			if element < 0 {
+				return nil, fmt.Errorf("Unknown board element %q", character)
+			}
+			container[x] = append(container[x], BoardElement(element))
+		}
+	}
+	return container, nil
+}
@@ -0,0 +1,89 @@
+package game
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarshalJSON(t *testing.T) {
+	assert := assert.New(t)
+
+	g := New()
+	g.Blocks[A] = blockShape(15)
+	g.Blocks[B] = createContainer(5)
+	g.Blocks[C] = blockShape(2)
+	g.Board[2][0] = Red
+	g.Board[2][1] = Red
+	g.Score = 4
+
+	data, err := json.Marshal(g)
+	assert.Nil(err)
+
+	var description map[string]interface{}
+	assert.Nil(json.Unmarshal(data, &description))
+	assert.Equal("rr........", description["board"].([]interface{})[2])
+	assert.Equal([]interface{}{"ww", "w"}, description["blocks"].([]interface{})[0])
+	assert.Equal([]interface{}{}, description["blocks"].([]interface{})[1])
+	assert.Equal(map[string]interface{}{"type": "uniform"}, description["generator"])
+	assert.Nil(description["shapes"])
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	assert := assert.New(t)
+
+	config := DefaultConfig()
+	config.Rows = 8
+	config.InvalidMove = IgnoreInvalidMove
+	catalogue := NewCatalogue("plus", NewShape("X", Yellow, ".#.", "###", ".#."), NewShape("dot", Red, "#"))
+	g := NewWithOptions(WithSeed(9), WithConfig(config), WithGenerator(NewBagGenerator()), WithCatalogue(catalogue))
+	for i := 0; i < 4; i++ {
+		moves := g.LegalMoves()
+		g.Move(moves[0].Block, moves[0].X, moves[0].Y)
+	}
+
+	data, err := json.Marshal(g)
+	assert.Nil(err)
+
+	var decoded Game
+	assert.Nil(json.Unmarshal(data, &decoded))
+
+	assert.Equal(g.Board, decoded.Board)
+	assert.Equal(g.Blocks, decoded.Blocks)
+	assert.Equal(g.Score, decoded.Score)
+	assert.Equal(g.Config(), decoded.Config())
+	assert.Equal(g.Seed(), decoded.Seed())
+	assert.Equal(catalogue.Shapes, decoded.Shapes().Shapes)
+
+	// decoded game continues the same way
+	for i := 0; i < 10; i++ {
+		moves := g.LegalMoves()
+		if len(moves) == 0 {
+			break
+		}
+		assert.Equal(moves, decoded.LegalMoves())
+		assert.Equal(g.Move(moves[0].Block, moves[0].X, moves[0].Y), decoded.Move(moves[0].Block, moves[0].X, moves[0].Y))
+		assert.Equal(g.Blocks, decoded.Blocks)
+	}
+}
+
+func TestUnmarshalJSONErrors(t *testing.T) {
+	assert := assert.New(t)
+
+	var g Game
+	assert.NotNil(json.Unmarshal([]byte(`{"config": {"rows": 2, "columns": 2, "blocks": 1}, "board": ["..", "."], "blocks": [[]]}`), &g))
+	assert.NotNil(json.Unmarshal([]byte(`{"config": {"rows": 1, "columns": 2, "blocks": 1}, "board": [".x"], "blocks": [[]]}`), &g))
+	assert.NotNil(json.Unmarshal([]byte(`{"config": {"rows": 1, "columns": 2, "blocks": 2}, "board": [".."], "blocks": [[]]}`), &g))
+	assert.NotNil(json.Unmarshal([]byte(`{"config": {"rows": 1, "columns": 2, "blocks": 1}, "board": [".."], "blocks": [[]], "generator": {"type": "other"}}`), &g))
+	assert.NotNil(json.Unmarshal([]byte(`{"config": {"rows": 1, "columns": 2, "blocks": 1}, "board": [".."], "blocks": [[]], "draws": 18446744073709551615}`), &g))
+	assert.NotNil(json.Unmarshal([]byte(`{"config": {"rows": 1, "columns": 2, "blocks": 1}, "board": [".."], "blocks": [[]], "moves": 2, "draws": 1000}`), &g))
+	assert.NotNil(json.Unmarshal([]byte(`{"config": {"rows": 1, "columns": 2, "blocks": 1}, "board": [".."], "blocks": [[]], "moves": -1}`), &g))
+	assert.Nil(json.Unmarshal([]byte(`{"config": {"rows": 1, "columns": 2, "blocks": 1}, "board": [".."], "blocks": [["r"]]}`), &g))
+	assert.Equal(Red, g.Blocks[A][0][0])
+
+	_, err := json.Marshal(NewWithOptions(WithGenerator(customGenerator{})))
+	assert.NotNil(err)
+}
+
+type customGenerator struct{ UniformGenerator }
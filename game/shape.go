@@ -0,0 +1,185 @@
+package game
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// blockSize is the minimal size of the square container holding a block
+const blockSize = 5
+
+// Cell is a single filled square of a Shape, X being the row and Y the column
+// in the same way as on the game board
+type Cell struct {
+	X int `json:"x" yaml:"x"`
+	Y int `json:"y" yaml:"y"`
+}
+
+// Shape describes a figure which can be dealt as a block
+type Shape struct {
+	ID    int          `json:"id" yaml:"id"`
+	Name  string       `json:"name" yaml:"name"`
+	Color BoardElement `json:"color" yaml:"color"`
+	Cells []Cell       `json:"cells" yaml:"cells"`
+}
+
+// NewShape creates Shape from rows of text where '#' marks filled cell and
+// any other character marks an empty one, eg. []string{"##", "#."}
+func NewShape(name string, color BoardElement, rows ...string) Shape {
+	shape := Shape{Name: name, Color: color}
+	for x, row := range rows {
+		for y, character := range []rune(row) {
+			if character == '#' {
+				shape.Cells = append(shape.Cells, Cell{x, y})
+			}
+		}
+	}
+	return shape.normalized()
+}
+
+// shapeFromGrid returns shape made of the filled cells of the grid
+func shapeFromGrid(grid [][]BoardElement) Shape {
+	var shape Shape
+	for x := range grid {
+		for y := range grid[x] {
+			if grid[x][y] != None {
+				shape.Cells = append(shape.Cells, Cell{x, y})
+			}
+		}
+	}
+	return shape
+}
+
+// Size returns number of rows and columns occupied by the shape
+func (shape Shape) Size() (rows int, columns int) {
+	for _, cell := range shape.Cells {
+		if cell.X+1 > rows {
+			rows = cell.X + 1
+		}
+		if cell.Y+1 > columns {
+			columns = cell.Y + 1
+		}
+	}
+	return rows, columns
+}
+
+// Grid returns the shape placed in top left corner of a square container.
+// Container has at least 5x5 size and grows for bigger shapes.
+func (shape Shape) Grid() [][]BoardElement {
+	size := blockSize
+	rows, columns := shape.Size()
+	if rows > size {
+		size = rows
+	}
+	if columns > size {
+		size = columns
+	}
+	grid := createContainer(size)
+	for _, cell := range shape.Cells {
+		grid[cell.X][cell.Y] = shape.Color
+	}
+	return grid
+}
+
+// Rotated returns the shape rotated by 90 degrees clockwise
+func (shape Shape) Rotated() Shape {
+	rows, _ := shape.Size()
+	rotated := shape
+	rotated.Cells = make([]Cell, len(shape.Cells))
+	for i, cell := range shape.Cells {
+		rotated.Cells[i] = Cell{cell.Y, rows - 1 - cell.X}
+	}
+	return rotated.normalized()
+}
+
+// Reflected returns the shape mirrored along the vertical axis
+func (shape Shape) Reflected() Shape {
+	_, columns := shape.Size()
+	reflected := shape
+	reflected.Cells = make([]Cell, len(shape.Cells))
+	for i, cell := range shape.Cells {
+		reflected.Cells[i] = Cell{cell.X, columns - 1 - cell.Y}
+	}
+	return reflected.normalized()
+}
+
+// SameCells checks if both shapes occupy exactly the same cells
+func (shape Shape) SameCells(other Shape) bool {
+	if len(shape.Cells) != len(other.Cells) {
+		return false
+	}
+	a := shape.normalized()
+	b := other.normalized()
+	for i := range a.Cells {
+		if a.Cells[i] != b.Cells[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// String returns rows of the shape, '#' for filled and '.' for empty cells
+func (shape Shape) String() string {
+	rows, columns := shape.Size()
+	grid := make([][]byte, rows)
+	for x := range grid {
+		grid[x] = []byte(strings.Repeat(".", columns))
+	}
+	for _, cell := range shape.Cells {
+		grid[cell.X][cell.Y] = '#'
+	}
+	lines := make([]string, rows)
+	for x := range grid {
+		lines[x] = string(grid[x])
+	}
+	return strings.Join(lines, "/")
+}
+
+// normalized returns copy of the shape moved to the top left corner with
+// cells sorted by row and column
+func (shape Shape) normalized() Shape {
+	if len(shape.Cells) == 0 {
+		return shape
+	}
+	minX, minY := shape.Cells[0].X, shape.Cells[0].Y
+	for _, cell := range shape.Cells {
+		if cell.X < minX {
+			minX = cell.X
+		}
+		if cell.Y < minY {
+			minY = cell.Y
+		}
+	}
+	normalized := shape
+	normalized.Cells = make([]Cell, len(shape.Cells))
+	for i, cell := range shape.Cells {
+		normalized.Cells[i] = Cell{cell.X - minX, cell.Y - minY}
+	}
+	sort.Slice(normalized.Cells, func(i, j int) bool {
+		if normalized.Cells[i].X != normalized.Cells[j].X {
+			return normalized.Cells[i].X < normalized.Cells[j].X
+		}
+		return normalized.Cells[i].Y < normalized.Cells[j].Y
+	})
+	return normalized
+}
+
+// validate checks if the shape can be used in the game
+func (shape Shape) validate() error {
+	if len(shape.Cells) == 0 {
+		return fmt.Errorf("Shape %q has no cells", shape.Name)
+	}
+	for _, cell := range shape.Cells {
+		if cell.X < 0 || cell.Y < 0 {
+			return fmt.Errorf("Shape %q has cell at negative position %d,%d", shape.Name, cell.X, cell.Y)
+		}
+	}
+	if shape.Color == None {
+		return fmt.Errorf("Shape %q has no color", shape.Name)
+	}
+	if shape.Color == Stone {
+		return fmt.Errorf("Shape %q cannot be made of stones", shape.Name)
+	}
+	return nil
+}
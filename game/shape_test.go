@@ -0,0 +1,57 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewShape(t *testing.T) {
+	assert := assert.New(t)
+
+	shape := NewShape("corner", White, "##", "#.")
+
+	assert.Equal([]Cell{{0, 0}, {0, 1}, {1, 0}}, shape.Cells)
+	assert.Equal("##/#.", shape.String())
+
+	rows, columns := NewShape("line", Blue, "####").Size()
+	assert.Equal(1, rows)
+	assert.Equal(4, columns)
+}
+
+func TestShapeGrid(t *testing.T) {
+	assert := assert.New(t)
+
+	grid := NewShape("corner", White, "##", "#.").Grid()
+
+	assert.Equal(5, len(grid))
+	assert.Equal(White, grid[0][0])
+	assert.Equal(White, grid[0][1])
+	assert.Equal(White, grid[1][0])
+	assert.Equal(None, grid[1][1])
+
+	grid = NewShape("long", Red, "#######").Grid()
+	assert.Equal(7, len(grid))
+	assert.Equal(7, len(grid[0]))
+}
+
+func TestShapeRotated(t *testing.T) {
+	assert := assert.New(t)
+
+	shape := NewShape("L", Cyan, "#.", "#.", "##")
+
+	assert.Equal("###/#..", shape.Rotated().String())
+	assert.Equal("##/.#/.#", shape.Rotated().Rotated().String())
+	assert.Equal("..#/###", shape.Rotated().Rotated().Rotated().String())
+	assert.True(shape.SameCells(shape.Rotated().Rotated().Rotated().Rotated()))
+}
+
+func TestShapeReflected(t *testing.T) {
+	assert := assert.New(t)
+
+	shape := NewShape("L", Cyan, "#.", "#.", "##")
+
+	assert.Equal(".#/.#/##", shape.Reflected().String())
+	assert.True(shape.SameCells(shape.Reflected().Reflected()))
+	assert.False(shape.SameCells(shape.Reflected()))
+}
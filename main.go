@@ -2,15 +2,18 @@ package main
 
 import (
 	"bufio"
+	"flag"
 	"fmt"
 	"os"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/wrutkowski/go1010/bot"
 	"github.com/wrutkowski/go1010/drawer"
 	"github.com/wrutkowski/go1010/game"
 	"github.com/wrutkowski/go1010/neural"
+	"github.com/wrutkowski/go1010/replay"
 )
 
 func main() {
@@ -19,12 +22,23 @@ func main() {
 	population := 500
 	drawEveryRun := false
 
-	neuralManager := neural.NewNetworkManager(175, 3, []int{200, 230, 170, 100, 32}, population)
-	games := make([]game.Game, population)
+	strategy := flag.String("seeds", "same", "block sequences of the games: same, generation, random or benchmark")
+	seed := flag.Int64("seed", game.DefaultSeed, "seed of the same game, base of generation seeds or the first benchmark seed")
+	benchmarkGames := flag.Int("benchmark-games", 50, "number of games in the benchmark set")
+	flag.Parse()
 
-	for i := 0; i < population; i++ {
-		games[i] = game.New()
+	seeds, err := seedProvider(*strategy, *seed, *benchmarkGames)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
 	}
+	config := game.DefaultConfig()
+	config.Engine = game.BitboardEngine
+
+	inputs := len(bot.NeuralInput(game.NewWithOptions(game.WithConfig(config))))
+	neuralManager := neural.NewNetworkManager(inputs, bot.NeuralOutputs(config), []int{200, 230, 170, 100, 32}, population)
+	games := newGames(seeds, config, neuralManager.GenerationNumber(), population)
+	replays := newReplays(games)
 
 	exit := false
 	generations := 0
@@ -36,6 +50,7 @@ func main() {
 	refreshBoardsRate := time.Duration(30 * time.Second)
 	loadFromFile := ""
 	saveToFile := ""
+	saveReplayToFile := ""
 
 	for {
 		untilTimeHasPassedDiff := time.Now().Sub(untilTimeHasPassed)
@@ -50,7 +65,7 @@ func main() {
 
 			if interactionEnabled {
 				runForSeconds := 0
-				exit, generations, steps, untilNextGeneration, untilFitnessIsAbove, runForSeconds, drawEveryRun, saveToFile, loadFromFile = nextCommand(drawEveryRun)
+				exit, generations, steps, untilNextGeneration, untilFitnessIsAbove, runForSeconds, drawEveryRun, saveToFile, loadFromFile, saveReplayToFile = nextCommand(drawEveryRun)
 				if runForSeconds > 0 {
 					untilTimeHasPassed = time.Now().Add(time.Second * time.Duration(runForSeconds))
 					refreshBoardsTimer = time.Now().Add(refreshBoardsRate)
@@ -69,12 +84,19 @@ func main() {
 						fmt.Println("Error while loading. ", error, "Press enter to continue...")
 						bufio.NewReader(os.Stdin).ReadBytes('\n')
 					} else {
-						for i := 0; i < population; i++ {
-							games[i] = game.New()
-						}
+						games = newGames(seeds, config, neuralManager.GenerationNumber(), population)
+						replays = newReplays(games)
 					}
 					loadFromFile = ""
 				}
+				if saveReplayToFile != "" {
+					fmt.Print("Saving replay...")
+					if error := bestReplay(games, replays).SaveToFile(saveReplayToFile); error != nil {
+						fmt.Println("Error while saving replay. ", error, "Press enter to continue...")
+						bufio.NewReader(os.Stdin).ReadBytes('\n')
+					}
+					saveReplayToFile = ""
+				}
 			}
 		}
 
@@ -101,10 +123,9 @@ func main() {
 			} else {
 				fmt.Println()
 			}
-			for i := 0; i < population; i++ {
-				games[i] = game.New()
-			}
 			neuralManager.NextGeneration()
+			games = newGames(seeds, config, neuralManager.GenerationNumber(), population)
+			replays = newReplays(games)
 
 			untilNextGeneration = false
 			if generations > 0 {
@@ -113,9 +134,14 @@ func main() {
 		}
 
 		for i := 0; i < population; i++ {
-			output := neuralManager.Networks[i].Run(inputForGame(games[i]))
-			block, x, y := outputToGameControl(output, len(games[i].Board))
-			errorGame := games[i].Move(block, x, y)
+			// fitness of finished game is final, including penalty for invalid move
+			if games[i].GameOver {
+				continue
+			}
+
+			move := bot.Neural{Network: neuralManager.Networks[i]}.ChooseMove(games[i])
+			errorGame := games[i].Play(move)
+			replays[i].Add(move, games[i].Score)
 
 			neuralManager.Networks[i].Fitness = calculateFitness(games[i], errorGame)
 
@@ -131,7 +157,7 @@ func main() {
 
 }
 
-func nextCommand(drawing bool) (exit bool, generations int, steps int, untilNextGeneration bool, untilFitnessIsAbove float32, runForSeconds int, drawingEnabled bool, saveToFile string, loadFromFile string) {
+func nextCommand(drawing bool) (exit bool, generations int, steps int, untilNextGeneration bool, untilFitnessIsAbove float32, runForSeconds int, drawingEnabled bool, saveToFile string, loadFromFile string, saveReplayToFile string) {
 	instructions := `Instructions:
 	Enter - next iteration
 	s NUM - skip NUM of steps
@@ -142,6 +168,7 @@ func nextCommand(drawing bool) (exit bool, generations int, steps int, untilNext
 	drawing on/off - enable/disable drawing each iteration
 	save filename - saves top performant Neural Network to a file
 	load filename - loads Neural Network to last place
+	replay filename - saves replay of the game with the highest score to a file
 	help - this help
 	e - exit`
 
@@ -154,11 +181,11 @@ func nextCommand(drawing bool) (exit bool, generations int, steps int, untilNext
 	components := strings.Split(text, " ")
 
 	if components[0] == "exit" || components[0] == "e" {
-		return true, 0, 0, false, 0, 0, drawing, "", ""
+		return true, 0, 0, false, 0, 0, drawing, "", "", ""
 	}
 
 	if components[0] == "ng" {
-		return false, 0, 0, true, 0, 0, drawing, "", ""
+		return false, 0, 0, true, 0, 0, drawing, "", "", ""
 	}
 
 	if components[0] == "s" {
@@ -167,7 +194,7 @@ func nextCommand(drawing bool) (exit bool, generations int, steps int, untilNext
 			return nextCommand(drawing)
 		}
 		s, _ := strconv.Atoi(components[1])
-		return false, 0, s, false, 0, 0, drawing, "", ""
+		return false, 0, s, false, 0, 0, drawing, "", "", ""
 	}
 
 	if components[0] == "g" {
@@ -176,7 +203,7 @@ func nextCommand(drawing bool) (exit bool, generations int, steps int, untilNext
 			return nextCommand(drawing)
 		}
 		g, _ := strconv.Atoi(components[1])
-		return false, g, 0, false, 0, 0, drawing, "", ""
+		return false, g, 0, false, 0, 0, drawing, "", "", ""
 	}
 
 	if components[0] == "f" {
@@ -185,7 +212,7 @@ func nextCommand(drawing bool) (exit bool, generations int, steps int, untilNext
 			return nextCommand(drawing)
 		}
 		f, _ := strconv.Atoi(components[1])
-		return false, 0, 0, false, float32(f), 0, drawing, "", ""
+		return false, 0, 0, false, float32(f), 0, drawing, "", "", ""
 	}
 
 	if components[0] == "t" {
@@ -194,7 +221,7 @@ func nextCommand(drawing bool) (exit bool, generations int, steps int, untilNext
 			return nextCommand(drawing)
 		}
 		t, _ := strconv.Atoi(components[1])
-		return false, 0, 0, false, 0, t, drawing, "", ""
+		return false, 0, 0, false, 0, t, drawing, "", "", ""
 	}
 
 	if components[0] == "drawing" {
@@ -203,9 +230,9 @@ func nextCommand(drawing bool) (exit bool, generations int, steps int, untilNext
 			return nextCommand(drawing)
 		}
 		if components[1] == "enable" || components[1] == "e" || components[1] == "1" {
-			return false, 0, 0, false, 0, 0, true, "", ""
+			return false, 0, 0, false, 0, 0, true, "", "", ""
 		} else {
-			return false, 0, 0, false, 0, 0, false, "", ""
+			return false, 0, 0, false, 0, 0, false, "", "", ""
 		}
 	}
 
@@ -215,7 +242,7 @@ func nextCommand(drawing bool) (exit bool, generations int, steps int, untilNext
 			return nextCommand(drawing)
 		}
 		save := components[1]
-		return false, 0, 0, false, 0, 0, drawing, save, ""
+		return false, 0, 0, false, 0, 0, drawing, save, "", ""
 	}
 
 	if components[0] == "load" {
@@ -224,7 +251,15 @@ func nextCommand(drawing bool) (exit bool, generations int, steps int, untilNext
 			return nextCommand(drawing)
 		}
 		load := components[1]
-		return false, 0, 0, false, 0, 0, drawing, "", load
+		return false, 0, 0, false, 0, 0, drawing, "", load, ""
+	}
+
+	if components[0] == "replay" {
+		if len(components) < 2 {
+			fmt.Println("Wrong command format. replay filename - saves replay of the game with the highest score to a file, eg. `replay game.replay`")
+			return nextCommand(drawing)
+		}
+		return false, 0, 0, false, 0, 0, drawing, "", "", components[1]
 	}
 
 	if components[0] == "help" {
@@ -232,72 +267,71 @@ func nextCommand(drawing bool) (exit bool, generations int, steps int, untilNext
 		return nextCommand(drawing)
 	}
 
-	return false, 0, 0, false, 0, 0, drawing, "", ""
+	return false, 0, 0, false, 0, 0, drawing, "", "", ""
 }
 
-func calculateFitness(g game.Game, errorGame error) float32 {
-	return float32(g.Score)
+// seedProvider returns provider deciding which block sequence is played by
+// each game:
+// - same - every network plays the same game in every generation
+// - generation - fresh game in each generation, same for all networks
+// - random - like generation with the base seed taken from current time
+// - benchmark - fixed set of games shared between generations
+func seedProvider(strategy string, seed int64, benchmarkGames int) (game.SeedProvider, error) {
+	switch strategy {
+	case "same":
+		return game.SameSeed(seed), nil
+	case "generation":
+		return game.GenerationSeed(seed), nil
+	case "random":
+		return game.NewTimeGenerationSeed(), nil
+	case "benchmark":
+		if benchmarkGames < 1 {
+			return nil, fmt.Errorf("Benchmark set needs at least 1 game")
+		}
+		seeds := make(game.BenchmarkSeeds, benchmarkGames)
+		for i := range seeds {
+			seeds[i] = seed + int64(i)
+		}
+		return seeds, nil
+	}
+	return nil, fmt.Errorf("Unknown seed strategy: %s", strategy)
 }
 
-func outputToGameControl(output []float32, boardSize int) (block game.BlockType, x int, y int) {
-	var outputBlock game.BlockType
-	if output[0] < -0.3333 {
-		outputBlock = game.A
-	} else if output[0] < 0.3333 {
-		outputBlock = game.B
-	} else {
-		outputBlock = game.C
+func newGames(seeds game.SeedProvider, config game.Config, generation int, population int) []game.Game {
+	games := make([]game.Game, population)
+	for i := 0; i < population; i++ {
+		games[i] = game.NewWithOptions(game.WithSeed(seeds.Seed(generation, i)), game.WithConfig(config))
 	}
+	return games
+}
 
-	outputX := ((output[1] + 1) / 2) * float32(boardSize)
-	outputY := ((output[2] + 1) / 2) * float32(boardSize)
+// invalidMovePenalty is subtracted from fitness of the network which ended
+// its game with a move that is not possible
+const invalidMovePenalty = 10
 
-	return outputBlock, int(outputX), int(outputY)
+func newReplays(games []game.Game) []replay.Replay {
+	replays := make([]replay.Replay, len(games))
+	for i := range games {
+		replays[i] = replay.New(games[i])
+	}
+	return replays
 }
 
-func inputForGame(g game.Game) []float32 {
-	input := make([]float32, len(g.Board)*len(g.Board)+len(g.BlockA)*len(g.BlockA)+len(g.BlockB)*len(g.BlockB)+len(g.BlockC)*len(g.BlockC))
-	i := 0
-	for x := 0; x < len(g.Board); x++ {
-		for y := 0; y < len(g.Board[0]); y++ {
-			if g.Board[x][y] != game.None {
-				input[i] = 1
-			} else {
-				input[i] = 0
-			}
-			i++
-		}
-	}
-	for x := 0; x < len(g.BlockA); x++ {
-		for y := 0; y < len(g.BlockA[0]); y++ {
-			if g.BlockA[x][y] != game.None {
-				input[i] = 1
-			} else {
-				input[i] = 0
-			}
-			i++
-		}
-	}
-	for x := 0; x < len(g.BlockB); x++ {
-		for y := 0; y < len(g.BlockB[0]); y++ {
-			if g.BlockB[x][y] != game.None {
-				input[i] = 1
-			} else {
-				input[i] = 0
-			}
-			i++
-		}
-	}
-	for x := 0; x < len(g.BlockC); x++ {
-		for y := 0; y < len(g.BlockC[0]); y++ {
-			if g.BlockC[x][y] != game.None {
-				input[i] = 1
-			} else {
-				input[i] = 0
-			}
-			i++
+// bestReplay returns replay of the game with the highest score
+func bestReplay(games []game.Game, replays []replay.Replay) replay.Replay {
+	best := 0
+	for i := range games {
+		if games[i].Score > games[best].Score {
+			best = i
 		}
 	}
+	return replays[best]
+}
 
-	return input
+func calculateFitness(g game.Game, errorGame error) float32 {
+	fitness := float32(g.Score)
+	if game.IsInvalidMove(errorGame) {
+		fitness -= invalidMovePenalty
+	}
+	return fitness
 }
@@ -123,3 +123,40 @@ func (network Network) Mutated() Network {
 	}
 	return mutant
 }
+
+// Weights returns all weights of the Neural Network layer by layer, neuron
+// by neuron, in the same order as they are saved to file
+func (network Network) Weights() []float32 {
+	var weights []float32
+	for layerIndex := 0; layerIndex < len(network.neuronLayers); layerIndex++ {
+		for neuronIndex := 0; neuronIndex < len(network.neuronLayers[layerIndex]); neuronIndex++ {
+			weights = append(weights, network.neuronLayers[layerIndex][neuronIndex].weights...)
+		}
+	}
+	return weights
+}
+
+// Clone returns copy of the Neural Network which can be run independently,
+// eg. in another goroutine
+func (network Network) Clone() Network {
+	clone := network
+	clone.neuronLayers = make([][]Neuron, len(network.neuronLayers))
+	for layerIndex := range network.neuronLayers {
+		clone.neuronLayers[layerIndex] = make([]Neuron, len(network.neuronLayers[layerIndex]))
+		for neuronIndex, neuron := range network.neuronLayers[layerIndex] {
+			neuron.weights = append([]float32(nil), neuron.weights...)
+			clone.neuronLayers[layerIndex][neuronIndex] = neuron
+		}
+	}
+	return clone
+}
+
+// Layers returns the number of neurons of each layer, the first one being
+// the number of inputs
+func (network Network) Layers() []int {
+	layers := make([]int, len(network.neuronLayers))
+	for layerIndex := range network.neuronLayers {
+		layers[layerIndex] = len(network.neuronLayers[layerIndex])
+	}
+	return layers
+}
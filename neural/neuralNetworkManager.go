@@ -2,8 +2,10 @@ package neural
 
 import (
 	"fmt"
+	"io"
 	"io/ioutil"
 	"math/rand"
+	"os"
 	"sort"
 	"strconv"
 	"strings"
@@ -13,6 +15,9 @@ import (
 // NetworkManager holds generation of neural networks, manages mutation and fitness
 type NetworkManager struct {
 	Networks []Network
+	// Output receives fitness of the top networks when the next generation
+	// is created, nothing is written when it is nil
+	Output io.Writer
 
 	generationNumber int
 	layers           []int
@@ -36,6 +41,7 @@ func NewNetworkManager(inputs int, outputs int, hiddenLayers []int, population i
 	randomProvider := RandomProvider{randomGenerator: rand.New(randomSource)}
 
 	var manager NetworkManager
+	manager.Output = os.Stdout
 	manager.randomProvider = randomProvider
 	manager.layers = makeLayers(inputs, outputs, hiddenLayers)
 
@@ -68,10 +74,12 @@ func (manager *NetworkManager) NextGeneration() {
 
 	population := manager.Population()
 	nextGeneration := make([]Network, population)
-	fmt.Printf("Network[0] fitness: %.0f        \n", manager.Networks[0].Fitness)
-	fmt.Printf("Network[1] fitness: %.0f        \n", manager.Networks[1].Fitness)
-	fmt.Printf("Network[2] fitness: %.0f        \n", manager.Networks[2].Fitness)
-	fmt.Print("\033[4A")
+	if manager.Output != nil {
+		fmt.Fprintf(manager.Output, "Network[0] fitness: %.0f        \n", manager.Networks[0].Fitness)
+		fmt.Fprintf(manager.Output, "Network[1] fitness: %.0f        \n", manager.Networks[1].Fitness)
+		fmt.Fprintf(manager.Output, "Network[2] fitness: %.0f        \n", manager.Networks[2].Fitness)
+		fmt.Fprint(manager.Output, "\033[4A")
+	}
 	for networkIndex := 0; networkIndex < population; networkIndex++ {
 		p := float32(networkIndex) / float32(population)
 		if networkIndex == 0 {
@@ -141,58 +149,92 @@ func (manager *NetworkManager) LoadFromFile(name string) error {
 		return err
 	}
 
-	content := string(data)
+	loadedNetwork, err := parseNetwork(string(data), manager.layers)
+	if err != nil {
+		return err
+	}
+	loadedNetwork.randomProvider = manager.Networks[0].randomProvider
+
+	manager.Networks[len(manager.Networks)-1] = loadedNetwork
+
+	return nil
+}
+
+// LoadNetwork loads and parses content of the file with given name saved by
+// SaveToFile, layers of the network are read from the file
+func LoadNetwork(name string) (Network, error) {
+	data, err := ioutil.ReadFile(name)
+	if err != nil {
+		return Network{}, err
+	}
+
+	network, err := parseNetwork(string(data), nil)
+	if err != nil {
+		return Network{}, err
+	}
+	network.randomProvider = NewRandomProvider()
+
+	return network, nil
+}
+
+// parseNetwork parses content saved by SaveToFile, layers of the content
+// must be equal to expected layers unless they are nil
+func parseNetwork(content string, expectedLayers []int) (Network, error) {
+	var network Network
 
 	contentComponents := strings.Split(content, "|")
 	if len(contentComponents) != 2 {
-		return fmt.Errorf("Incorrect file format")
+		return network, fmt.Errorf("Incorrect file format")
 	}
 
 	// layers
 	layersComponents := strings.Split(contentComponents[0], ",")
 
-	if len(layersComponents) != len(manager.layers) {
-		return fmt.Errorf("Incompatible layer setting")
+	if expectedLayers != nil && len(layersComponents) != len(expectedLayers) {
+		return network, fmt.Errorf("Incompatible layer setting")
 	}
-	for layerIndex := 0; layerIndex < len(manager.layers); layerIndex++ {
+	layers := make([]int, len(layersComponents))
+	for layerIndex := 0; layerIndex < len(layersComponents); layerIndex++ {
 		parsedLayer, parseError := strconv.Atoi(layersComponents[layerIndex])
 		if parseError != nil {
-			return parseError
+			return network, parseError
+		}
+		if expectedLayers != nil && parsedLayer != expectedLayers[layerIndex] {
+			return network, fmt.Errorf("Incompatible layer setting. Parsed: %d, expected: %d", parsedLayer, expectedLayers[layerIndex])
 		}
-		if parsedLayer != manager.layers[layerIndex] {
-			return fmt.Errorf("Incompatible layer setting. Parsed: %d, expected: %d", parsedLayer, manager.layers[layerIndex])
+		if parsedLayer < 1 {
+			return network, fmt.Errorf("Incorrect layer size: %d", parsedLayer)
 		}
+		layers[layerIndex] = parsedLayer
 	}
 
 	// weights
 	weightsComponents := strings.Split(contentComponents[1], ",")
 	loadedWeightIndex := 0
-	network := manager.Networks[0]
-	var loadedNetwork Network
-	loadedNetwork.randomProvider = network.randomProvider
-	loadedNetwork.neuronLayers = make([][]Neuron, len(network.neuronLayers))
-	for layerIndex := 0; layerIndex < len(network.neuronLayers); layerIndex++ {
-		loadedNetwork.neuronLayers[layerIndex] = make([]Neuron, len(network.neuronLayers[layerIndex]))
-		for neuronIndex := 0; neuronIndex < len(network.neuronLayers[layerIndex]); neuronIndex++ {
+	network.neuronLayers = make([][]Neuron, len(layers))
+	for layerIndex := 0; layerIndex < len(layers); layerIndex++ {
+		network.neuronLayers[layerIndex] = make([]Neuron, layers[layerIndex])
+		if layerIndex == 0 {
+			continue
+		}
+		for neuronIndex := 0; neuronIndex < layers[layerIndex]; neuronIndex++ {
 			var neuron Neuron
-			neuron.weights = make([]float32, len(network.neuronLayers[layerIndex][neuronIndex].weights))
-			for weightIndex := 0; weightIndex < len(network.neuronLayers[layerIndex][neuronIndex].weights); weightIndex++ {
+			neuron.weights = make([]float32, layers[layerIndex-1])
+			for weightIndex := 0; weightIndex < len(neuron.weights); weightIndex++ {
 				if loadedWeightIndex > len(weightsComponents)-1 {
-					return fmt.Errorf("Incompatible weights length")
+					return network, fmt.Errorf("Incompatible weights length")
 				}
 				parsedWeight, parseError := strconv.ParseFloat(weightsComponents[loadedWeightIndex], 32)
 				if parseError != nil {
-					return parseError
+					return network, parseError
 				}
 				neuron.weights[weightIndex] = float32(parsedWeight)
 
 				loadedWeightIndex++
 			}
-			loadedNetwork.neuronLayers[layerIndex][neuronIndex] = neuron
+			network.neuronLayers[layerIndex][neuronIndex] = neuron
 		}
 	}
 
-	manager.Networks[len(manager.Networks)-1] = loadedNetwork
-
-	return nil
+	return network, nil
 }
@@ -1,6 +1,7 @@
 package neural
 
 import (
+	"bytes"
 	"io/ioutil"
 	"os"
 	"testing"
@@ -28,6 +29,8 @@ func TestNextGeneration(t *testing.T) {
 	assert := assert.New(t)
 
 	manager := NewNetworkManager(2, 2, []int{3}, 10)
+	var output bytes.Buffer
+	manager.Output = &output
 	manager.Networks[5].Fitness = 10
 	network1 := manager.Networks[0]
 	network2 := manager.Networks[1]
@@ -43,6 +46,7 @@ func TestNextGeneration(t *testing.T) {
 	manager.NextGeneration()
 
 	assert.Equal(1, manager.GenerationNumber())
+	assert.Contains(output.String(), "Network[0] fitness: 10")
 
 	assert.Equal(network6, manager.Networks[0]) // highest Fitness is moved to next generation
 	assert.NotEqual(network1, manager.Networks[0])
@@ -186,3 +190,23 @@ func TestLoadFromFileIncorrectFormat(t *testing.T) {
 	// clean up
 	os.Remove("./TestLoadFromFileIncorrectFormat.neural")
 }
+
+func TestLoadNetwork(t *testing.T) {
+	assert := assert.New(t)
+	ioutil.WriteFile("./TestLoadNetwork.neural", []byte("2,1|0.500000,-0.250000"), 0644)
+
+	network, loadError := LoadNetwork("./TestLoadNetwork.neural")
+	assert.Nil(loadError)
+	assert.Equal([]float32{0.5, -0.25}, network.Weights())
+	assert.Equal(2, len(network.neuronLayers))
+
+	ioutil.WriteFile("./TestLoadNetwork.neural", []byte("2,1|0.500000"), 0644)
+	_, loadError = LoadNetwork("./TestLoadNetwork.neural")
+	assert.NotNil(loadError)
+
+	_, loadError = LoadNetwork("./NonExistentFile")
+	assert.NotNil(loadError)
+
+	// clean up
+	os.Remove("./TestLoadNetwork.neural")
+}
@@ -156,3 +156,31 @@ func TestMutatedSubtraction(t *testing.T) {
 	assert.Equal(float32(0.225), mutant.neuronLayers[2][1].weights[1])
 	assert.Equal(float32(0.225), mutant.neuronLayers[2][1].weights[2])
 }
+
+func TestWeights(t *testing.T) {
+	assert := assert.New(t)
+
+	neuralNetwork := NewNetwork([]int{2, 3, 2}, StubRandomProvider{StubNextRange: 0.25})
+	neuralNetwork.neuronLayers[1][1].weights[0] = 0.5
+	neuralNetwork.neuronLayers[2][1].weights[2] = -1
+
+	weights := neuralNetwork.Weights()
+
+	assert.Equal(12, len(weights))
+	assert.Equal(float32(0.5), weights[2])
+	assert.Equal(float32(-1), weights[11])
+}
+
+func TestClone(t *testing.T) {
+	assert := assert.New(t)
+
+	neuralNetwork := NewNetwork([]int{2, 3, 2}, StubRandomProvider{StubNextRange: 0.25})
+	clone := neuralNetwork.Clone()
+	clone.neuronLayers[1][0].weights[0] = 0.5
+	clone.Run([]float32{1, 1})
+
+	assert.Equal(float32(0.25), neuralNetwork.neuronLayers[1][0].weights[0])
+	assert.Equal(float32(0), neuralNetwork.neuronLayers[0][0].value)
+	assert.Equal([]int{2, 3, 2}, clone.Layers())
+	assert.Equal(neuralNetwork.Run([]float32{1, 1}), neuralNetwork.Clone().Run([]float32{1, 1}))
+}
@@ -2,11 +2,16 @@ package main
 
 import (
 	"bufio"
+	"encoding/json"
+	"flag"
 	"fmt"
+	"io/ioutil"
 	"os"
 	"strconv"
 	"strings"
 
+	"github.com/wrutkowski/go1010/arena"
+	"github.com/wrutkowski/go1010/bot"
 	"github.com/wrutkowski/go1010/drawer"
 	"github.com/wrutkowski/go1010/game"
 )
@@ -14,22 +19,119 @@ import (
 type boardElement int
 
 func main() {
+	extensions := flag.Bool("extensions", false, "enable hold slot, rotation and 3 rerolls")
+	moveLimit := flag.Int("moves", 0, "end the game after a given number of placed blocks")
+	timeLimit := flag.Duration("time", 0, "end the game after a given time, eg. 5m")
+	botName := flag.String("bot", "search", "bot giving hints and analysis: greedy, linear, search, expectimax or mcts")
+	flag.Parse()
 
-	g := game.New()
+	config := game.DefaultConfig()
+	config.InvalidMove = game.IgnoreInvalidMove
+	config.Scoring = game.ComboScoring
+	if *extensions {
+		config.Hold = true
+		config.Rotation = true
+		config.Rerolls = 3
+	}
+	config.MoveLimit = *moveLimit
+	config.TimeLimit = *timeLimit
+
+	hintPlayer, err := newHintPlayer(*botName, config)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	// points of the last move are shown below the board
+	var lastScore *game.Event
+	scoreObserver := game.ObserverFunc(func(event game.Event) {
+		if event.Type == game.ScoreChangedEvent {
+			lastScore = &event
+		}
+	})
+	g := game.NewWithOptions(game.WithConfig(config), game.WithHistory(), game.WithObserver(scoreObserver))
 
 	drawer.PrepareTerminal()
 	drawer.DrawGame(g)
 
 	for {
-		block, x, y, exit := nextMoveInteractive()
-		if exit {
+		block, x, y, command, argument := nextMoveInteractive()
+		if command == "exit" {
 			break
 		}
 
-		g.Move(block, x, y)
+		switch command {
+		case "undo":
+			if err := g.Undo(); err != nil {
+				fmt.Println(err)
+				continue
+			}
+		case "redo":
+			if err := g.Redo(); err != nil {
+				fmt.Println(err)
+				continue
+			}
+		case "save":
+			if err := saveGame(g, argument); err != nil {
+				fmt.Println("Error while saving.", err)
+			}
+			continue
+		case "load":
+			loaded, err := loadGame(argument)
+			if err != nil {
+				fmt.Println("Error while loading.", err)
+				continue
+			}
+			// rules of the loaded game may differ from the flags, eg.
+			// size of the board or the extensions
+			player, err := newHintPlayer(*botName, loaded.Config())
+			if err != nil {
+				fmt.Println("Error while loading.", err)
+				continue
+			}
+			hintPlayer = player
+			g = loaded
+			g.Observe(scoreObserver)
+		case "hint":
+			move := hintPlayer.ChooseMove(g)
+			drawer.PrepareTerminal()
+			drawer.DrawGameWithHint(g, move)
+			fmt.Println("Hint:", moveCommand(move))
+			continue
+		case "analyze":
+			top, err := strconv.Atoi(argument)
+			if err != nil || top < 1 {
+				top = defaultAnalyzeTop
+			}
+			candidates := hintPlayer.Candidates(g)
+			drawer.PrepareTerminal()
+			if len(candidates) > 0 {
+				drawer.DrawGameWithHint(g, candidates[0].Move)
+			} else {
+				drawer.DrawGame(g)
+			}
+			printCandidates(candidates, top)
+			continue
+		case "hold", "rotate", "reroll":
+			action := map[string]game.Action{"hold": game.HoldBlock, "rotate": game.RotateBlock, "reroll": game.Reroll}[command]
+			if err := g.Play(game.Move{Block: block, Action: action}); game.IsInvalidMove(err) {
+				fmt.Println(err)
+				continue
+			}
+		default:
+			// invalid move is reported without ending the game
+			if err := g.Move(block, x, y); game.IsInvalidMove(err) {
+				fmt.Println(err)
+				continue
+			}
+		}
 
 		drawer.PrepareTerminal()
 		drawer.DrawGame(g)
+		if lastScore != nil {
+			printScore(*lastScore)
+			lastScore = nil
+		}
 
 		if g.GameOver {
 			fmt.Println("GAME OVER")
@@ -38,36 +140,157 @@ func main() {
 	}
 }
 
-func nextMoveInteractive() (block game.BlockType, x int, y int, exit bool) {
+// nextMoveInteractive reads block and position of the next move or one of the
+// commands: exit (e), undo (u), redo (r), save filename, load filename, hint,
+// analyze with optional number of placements and, when extensions are
+// enabled, hold block, rotate block and reroll. Block in the hold slot is
+// selected with h.
+func nextMoveInteractive() (block game.BlockType, x int, y int, command string, argument string) {
 	fmt.Print("Next move: ")
 
 	reader := bufio.NewReader(os.Stdin)
 	text, _ := reader.ReadString('\n')
 	text = text[:len(text)-1]
 
-	if text == "exit" || text == "e" {
-		return 0, 0, 0, true
+	switch text {
+	case "exit", "e":
+		return 0, 0, 0, "exit", ""
+	case "undo", "u":
+		return 0, 0, 0, "undo", ""
+	case "redo", "r":
+		return 0, 0, 0, "redo", ""
+	case "reroll":
+		return 0, 0, 0, "reroll", ""
+	case "hint":
+		return 0, 0, 0, "hint", ""
 	}
 
 	components := strings.Split(text, " ")
+	if components[0] == "save" || components[0] == "load" {
+		if len(components) < 2 {
+			fmt.Printf("Wrong command format. %s filename, eg. `%s game.json`\n", components[0], components[0])
+			return nextMoveInteractive()
+		}
+		return 0, 0, 0, components[0], components[1]
+	}
+
+	if components[0] == "analyze" {
+		if len(components) > 1 {
+			return 0, 0, 0, "analyze", components[1]
+		}
+		return 0, 0, 0, "analyze", ""
+	}
+
+	if components[0] == "hold" || components[0] == "rotate" {
+		if len(components) < 2 {
+			fmt.Printf("Wrong command format. %s block, eg. `%s 1`\n", components[0], components[0])
+			return nextMoveInteractive()
+		}
+		return parseBlock(components[1]), 0, 0, components[0], ""
+	}
+
 	if len(components) != 3 {
-		fmt.Println("Provide 3 components: 0-2 for block number and X and Y position of block placement...")
+		fmt.Println("Provide 3 components: block number starting from 0 (h for the held block) and X and Y position of block placement, or u to undo, r to redo, hold/rotate block, reroll, hint, analyze [number], save/load filename, e to exit...")
 		return nextMoveInteractive()
 	}
 
-	blockNumber, _ := strconv.Atoi(components[0])
 	positionX, _ := strconv.Atoi(components[1])
 	positionY, _ := strconv.Atoi(components[2])
 
-	var selectedBlock game.BlockType
-	switch blockNumber {
-	case 0:
-		selectedBlock = game.A
-	case 1:
-		selectedBlock = game.B
-	case 2:
-		selectedBlock = game.C
+	return parseBlock(components[0]), positionX, positionY, "", ""
+}
+
+// parseBlock returns block with a given number, h selects the hold slot
+func parseBlock(text string) game.BlockType {
+	if text == "h" {
+		return game.Hold
 	}
+	blockNumber, _ := strconv.Atoi(text)
+	return game.BlockType(blockNumber)
+}
 
-	return selectedBlock, positionX, positionY, false
+// defaultAnalyzeTop is the number of placements shown by analyze command
+// without argument
+const defaultAnalyzeTop = 5
+
+// newHintPlayer returns the arena player of a given name, it has to value
+// moves to give hints and analysis
+func newHintPlayer(name string, config game.Config) (bot.Analyzer, error) {
+	entrant, err := arena.NewEntrant(name, config)
+	if err != nil {
+		return nil, err
+	}
+	analyzer, ok := entrant.New(game.DefaultSeed).(bot.Analyzer)
+	if !ok {
+		return nil, fmt.Errorf("Player %s cannot give hints", name)
+	}
+	return analyzer, nil
+}
+
+// moveCommand returns the command making the move, eg. `1 3 4` or `reroll`
+func moveCommand(move game.Move) string {
+	block := strconv.Itoa(int(move.Block))
+	if move.Block == game.Hold {
+		block = "h"
+	}
+	switch move.Action {
+	case game.HoldBlock:
+		return "hold " + block
+	case game.RotateBlock:
+		return "rotate " + block
+	case game.Reroll:
+		return "reroll"
+	}
+	return fmt.Sprintf("%s %d %d", block, move.X, move.Y)
+}
+
+// printCandidates lists top placements of the bot with their values, the
+// first one is highlighted on the board
+func printCandidates(candidates []bot.Candidate, top int) {
+	if len(candidates) == 0 {
+		fmt.Println("No block can be placed")
+		return
+	}
+	if top > len(candidates) {
+		top = len(candidates)
+	}
+	fmt.Printf("Top %d of %d placements:\n", top, len(candidates))
+	for i, candidate := range candidates[:top] {
+		fmt.Printf("%d. %-8s %.2f\n", i+1, moveCommand(candidate.Move), candidate.Value)
+	}
+}
+
+// printScore shows how points of the move were counted
+func printScore(event game.Event) {
+	breakdown := event.Breakdown
+	fmt.Printf("+%d points: %d for cells, %d for lines", event.ScoreDelta, breakdown.Cells, breakdown.Lines)
+	if breakdown.Combo > 0 {
+		fmt.Printf(", %d combo bonus", breakdown.Combo)
+	}
+	if breakdown.Streak > 0 {
+		fmt.Printf(", %d streak bonus", breakdown.Streak)
+	}
+	fmt.Println()
+}
+
+// saveGame writes the game to JSON file with a given name
+func saveGame(g game.Game, name string) error {
+	data, err := json.Marshal(g)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(name, data, 0644)
+}
+
+// loadGame reads the game saved by saveGame, loaded game keeps history of
+// moves made after loading
+func loadGame(name string) (game.Game, error) {
+	var g game.Game
+	data, err := ioutil.ReadFile(name)
+	if err != nil {
+		return g, err
+	}
+	err = json.Unmarshal(data, &g)
+	g.EnableHistory()
+	return g, err
 }
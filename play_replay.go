@@ -0,0 +1,79 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/wrutkowski/go1010/drawer"
+	"github.com/wrutkowski/go1010/replay"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Println("Provide replay file name, eg. `go run play_replay.go game.replay`")
+		return
+	}
+
+	r, err := replay.LoadFromFile(os.Args[1])
+	if err != nil {
+		fmt.Println("Error while loading replay.", err)
+		return
+	}
+
+	_, verificationError := replay.Play(r)
+	stepper := replay.NewStepper(r)
+
+	for {
+		drawer.PrepareTerminal()
+		drawer.DrawGameWithTitle(stepper.Game(), fmt.Sprintf("replay %d/%d", stepper.Position(), stepper.Len()))
+		if move, ok := stepper.LastMove(); ok {
+			fmt.Printf("Last move: block %d at %d,%d\n", move.Block, move.X, move.Y)
+		}
+		if verificationError != nil {
+			fmt.Println("Replay verification failed:", verificationError)
+		}
+
+		command, argument := nextReplayCommand()
+		switch command {
+		case "exit":
+			return
+		case "next":
+			stepper.Next()
+		case "previous":
+			stepper.Previous()
+		case "seek":
+			stepper.Seek(argument)
+		}
+	}
+}
+
+// nextReplayCommand reads one of the commands: Enter or n - next move,
+// p - previous move, s NUM - go to position after NUM moves, e - exit
+func nextReplayCommand() (command string, argument int) {
+	fmt.Print("Command (Enter/n - next, p - previous, s NUM - seek, e - exit): ")
+
+	reader := bufio.NewReader(os.Stdin)
+	text, _ := reader.ReadString('\n')
+	text = strings.TrimSpace(text)
+
+	components := strings.Split(text, " ")
+	switch components[0] {
+	case "", "n":
+		return "next", 0
+	case "p":
+		return "previous", 0
+	case "e", "exit":
+		return "exit", 0
+	case "s":
+		if len(components) < 2 {
+			fmt.Println("Wrong command format. s NUM - go to position after NUM moves, eg. `s 10`")
+			return nextReplayCommand()
+		}
+		position, _ := strconv.Atoi(components[1])
+		return "seek", position
+	}
+	return nextReplayCommand()
+}
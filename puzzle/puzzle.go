@@ -0,0 +1,232 @@
+// Package puzzle implements puzzle mode of the game: a preset starting board,
+// possibly with stones, a fixed sequence of blocks and a goal to reach.
+package puzzle
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/wrutkowski/go1010/game"
+	"gopkg.in/yaml.v3"
+)
+
+// GoalType decides what has to be done to solve the puzzle
+type GoalType int
+
+// GoalType can be one of the following values
+const (
+	// ClearLines requires removing Target rows or columns
+	ClearLines GoalType = 1
+	// ReachScore requires reaching score of Target points
+	ReachScore GoalType = 2
+	// SurviveMoves requires placing Target blocks
+	SurviveMoves GoalType = 3
+)
+
+var goalTypeNames = []string{"", "lines", "score", "moves"}
+
+// String returns name of the goal type used in puzzle files
+func (goalType GoalType) String() string {
+	if goalType <= 0 || int(goalType) >= len(goalTypeNames) {
+		return fmt.Sprintf("GoalType(%d)", int(goalType))
+	}
+	return goalTypeNames[goalType]
+}
+
+// MarshalText encodes GoalType as its name
+func (goalType GoalType) MarshalText() ([]byte, error) {
+	if goalType <= 0 || int(goalType) >= len(goalTypeNames) {
+		return nil, fmt.Errorf("Unknown goal type %d", int(goalType))
+	}
+	return []byte(goalType.String()), nil
+}
+
+// UnmarshalText decodes GoalType from its name
+func (goalType *GoalType) UnmarshalText(text []byte) error {
+	for value, name := range goalTypeNames {
+		if value > 0 && name == strings.ToLower(string(text)) {
+			*goalType = GoalType(value)
+			return nil
+		}
+	}
+	return fmt.Errorf("Unknown goal type %q", string(text))
+}
+
+// Goal of the puzzle
+type Goal struct {
+	Type   GoalType `json:"type" yaml:"type"`
+	Target int      `json:"target" yaml:"target"`
+}
+
+// String describes the goal, eg. "clear 3 lines"
+func (goal Goal) String() string {
+	switch goal.Type {
+	case ClearLines:
+		return fmt.Sprintf("clear %d lines", goal.Target)
+	case ReachScore:
+		return fmt.Sprintf("reach score %d", goal.Target)
+	case SurviveMoves:
+		return fmt.Sprintf("survive %d moves", goal.Target)
+	}
+	return "unknown goal"
+}
+
+// progress returns value compared with the target of the goal
+func (goal Goal) progress(score int, moves int, lines int) int {
+	switch goal.Type {
+	case ClearLines:
+		return lines
+	case ReachScore:
+		return score
+	case SurviveMoves:
+		return moves
+	}
+	return 0
+}
+
+// Puzzle describes starting position, blocks dealt in the game and its goal
+type Puzzle struct {
+	Name string
+	// Board is the starting board, it can contain stones
+	Board [][]game.BoardElement
+	// Sequence contains IDs of shapes of Shapes catalogue dealt in the game,
+	// puzzle is failed when all of them are placed and the goal is not reached
+	Sequence []int
+	Goal     Goal
+	// Config sets rules of the game, size of the board is taken from Board
+	Config game.Config
+	Shapes *game.Catalogue
+}
+
+// Game returns the game in the starting position of the puzzle
+func (puzzle Puzzle) Game(options ...game.Option) game.Game {
+	options = append([]game.Option{
+		game.WithConfig(puzzle.Config),
+		game.WithBoard(puzzle.Board),
+		game.WithCatalogue(puzzle.Shapes),
+		game.WithGenerator(game.NewScriptedGenerator(puzzle.Sequence...)),
+	}, options...)
+	return game.NewWithOptions(options...)
+}
+
+// Validate checks if the puzzle is correctly described, it does not check
+// if it can be solved
+func (puzzle Puzzle) Validate() error {
+	if len(puzzle.Board) == 0 || len(puzzle.Board[0]) == 0 {
+		return fmt.Errorf("Puzzle %q has empty board", puzzle.Name)
+	}
+	if puzzle.Shapes == nil || puzzle.Shapes.Len() == 0 {
+		return fmt.Errorf("Puzzle %q has no shapes", puzzle.Name)
+	}
+	if len(puzzle.Sequence) == 0 {
+		return fmt.Errorf("Puzzle %q has no blocks", puzzle.Name)
+	}
+	for _, id := range puzzle.Sequence {
+		if _, ok := puzzle.Shapes.Shape(id); !ok {
+			return fmt.Errorf("Puzzle %q uses unknown shape %d", puzzle.Name, id)
+		}
+	}
+	blocks := puzzle.Game().Config().Blocks
+	if len(puzzle.Sequence)%blocks != 0 {
+		return fmt.Errorf("Number of blocks of puzzle %q must be a multiple of %d", puzzle.Name, blocks)
+	}
+	if puzzle.Goal.Type < ClearLines || puzzle.Goal.Type > SurviveMoves || puzzle.Goal.Target <= 0 {
+		return fmt.Errorf("Puzzle %q has incorrect goal", puzzle.Name)
+	}
+	if puzzle.Goal.Type == SurviveMoves && puzzle.Goal.Target > len(puzzle.Sequence) {
+		return fmt.Errorf("Puzzle %q requires more moves than it has blocks", puzzle.Name)
+	}
+	return nil
+}
+
+// puzzleFile is the format of the file loaded by LoadPuzzle
+type puzzleFile struct {
+	Name     string   `json:"name" yaml:"name"`
+	Board    []string `json:"board" yaml:"board"`
+	Blocks   int      `json:"blocks" yaml:"blocks"`
+	Sequence []string `json:"sequence" yaml:"sequence"`
+	Goal     Goal     `json:"goal" yaml:"goal"`
+}
+
+// LoadPuzzle reads puzzle from JSON or YAML file, format is chosen by the
+// file extension (.json, .yaml or .yml)
+func LoadPuzzle(name string) (Puzzle, error) {
+	data, err := ioutil.ReadFile(name)
+	if err != nil {
+		return Puzzle{}, err
+	}
+
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".json":
+		return ParsePuzzleJSON(data)
+	case ".yaml", ".yml":
+		return ParsePuzzleYAML(data)
+	}
+	return Puzzle{}, fmt.Errorf("Unsupported puzzle file format %q", filepath.Ext(name))
+}
+
+// ParsePuzzleJSON parses puzzle described in JSON format. Board uses symbols
+// of the game notation, '.' for empty cell and 's' for a stone. Blocks are
+// names of shapes of the default catalogue, eg.
+//
+//	{"name": "corners", "board": ["s...s", ".....", "s...s"],
+//	 "sequence": ["dot", "five horizontal", "square two"],
+//	 "goal": {"type": "lines", "target": 1}}
+func ParsePuzzleJSON(data []byte) (Puzzle, error) {
+	var file puzzleFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return Puzzle{}, err
+	}
+	return file.puzzle()
+}
+
+// ParsePuzzleYAML parses puzzle described in YAML format using the same
+// fields as ParsePuzzleJSON
+func ParsePuzzleYAML(data []byte) (Puzzle, error) {
+	var file puzzleFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return Puzzle{}, err
+	}
+	return file.puzzle()
+}
+
+func (file puzzleFile) puzzle() (Puzzle, error) {
+	board, err := game.ParseRows(file.Board)
+	if err != nil {
+		return Puzzle{}, err
+	}
+
+	puzzle := Puzzle{
+		Name:   file.Name,
+		Board:  board,
+		Goal:   file.Goal,
+		Config: game.DefaultConfig(),
+		Shapes: game.DefaultCatalogue(),
+	}
+	if file.Blocks > 0 {
+		puzzle.Config.Blocks = file.Blocks
+	}
+	for _, name := range file.Sequence {
+		id := shapeByName(puzzle.Shapes, name)
+		if id < 0 {
+			return Puzzle{}, fmt.Errorf("Unknown shape %q", name)
+		}
+		puzzle.Sequence = append(puzzle.Sequence, id)
+	}
+
+	return puzzle, puzzle.Validate()
+}
+
+// shapeByName returns ID of the shape with a given name, -1 is returned when
+// there is no such shape in the catalogue
+func shapeByName(catalogue *game.Catalogue, name string) int {
+	for _, shape := range catalogue.Shapes {
+		if strings.EqualFold(shape.Name, name) {
+			return shape.ID
+		}
+	}
+	return -1
+}
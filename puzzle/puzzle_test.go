@@ -0,0 +1,65 @@
+package puzzle
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wrutkowski/go1010/game"
+)
+
+func TestLoadPuzzle(t *testing.T) {
+	assert := assert.New(t)
+
+	puzzle, err := LoadPuzzle("testdata/cross.yaml")
+	assert.Nil(err)
+	assert.Equal("cross", puzzle.Name)
+	assert.Equal(Goal{ClearLines, 6}, puzzle.Goal)
+	assert.Equal([]int{7, 8, 9}, puzzle.Sequence)
+	assert.Equal(game.Stone, puzzle.Board[0][0])
+	assert.Equal(game.None, puzzle.Board[0][2])
+	assert.Equal("clear 6 lines", puzzle.Goal.String())
+
+	g := puzzle.Game()
+	assert.Equal(5, g.Config().Rows)
+	assert.Equal(puzzle.Board, g.Board)
+	assert.Equal(puzzle.Shapes.Shapes[7].Grid(), g.Blocks[game.A])
+	assert.Equal(puzzle.Shapes.Shapes[9].Grid(), g.Blocks[game.C])
+
+	puzzle, err = LoadPuzzle("testdata/pillars.yaml")
+	assert.Nil(err)
+	assert.Equal(2, puzzle.Game().Config().Blocks)
+	assert.Equal(Goal{SurviveMoves, 6}, puzzle.Goal)
+
+	puzzle, err = LoadPuzzle("testdata/impossible.json")
+	assert.Nil(err)
+	assert.Equal([]int{0, 0, 0}, puzzle.Sequence)
+
+	_, err = LoadPuzzle("testdata/missing.yaml")
+	assert.NotNil(err)
+	_, err = LoadPuzzle("puzzle.go")
+	assert.NotNil(err)
+}
+
+func TestParsePuzzleErrors(t *testing.T) {
+	assert := assert.New(t)
+
+	testCases := []string{
+		`{"board": [], "sequence": ["dot"], "goal": {"type": "lines", "target": 1}}`,
+		`{"board": ["..", "..."], "sequence": ["dot"], "goal": {"type": "lines", "target": 1}}`,
+		`{"board": ["..x"], "sequence": ["dot"], "goal": {"type": "lines", "target": 1}}`,
+		`{"board": ["..."], "sequence": [], "goal": {"type": "lines", "target": 1}}`,
+		`{"board": ["..."], "sequence": ["dot", "dot"], "goal": {"type": "lines", "target": 1}}`,
+		`{"board": ["..."], "sequence": ["triangle"], "blocks": 1, "goal": {"type": "lines", "target": 1}}`,
+		`{"board": ["..."], "sequence": ["dot"], "blocks": 1, "goal": {"type": "time", "target": 1}}`,
+		`{"board": ["..."], "sequence": ["dot"], "blocks": 1, "goal": {"type": "score", "target": 0}}`,
+		`{"board": ["..."], "sequence": ["dot"], "blocks": 1, "goal": {"type": "moves", "target": 2}}`,
+	}
+
+	for _, testCase := range testCases {
+		_, err := ParsePuzzleJSON([]byte(testCase))
+		assert.NotNil(err, testCase)
+	}
+
+	_, err := ParsePuzzleJSON([]byte(`{"board": ["..."], "sequence": ["Dot"], "blocks": 1, "goal": {"type": "score", "target": 1}}`))
+	assert.Nil(err)
+}
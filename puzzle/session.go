@@ -0,0 +1,93 @@
+package puzzle
+
+import (
+	"fmt"
+
+	"github.com/wrutkowski/go1010/game"
+)
+
+// Status of the puzzle being played
+type Status int
+
+// Status can be one of the following values
+const (
+	InProgress Status = 0
+	Solved     Status = 1
+	Failed     Status = 2
+)
+
+var statusNames = []string{"in progress", "solved", "failed"}
+
+// String returns name of the status
+func (status Status) String() string {
+	if status < 0 || int(status) >= len(statusNames) {
+		return fmt.Sprintf("Status(%d)", int(status))
+	}
+	return statusNames[status]
+}
+
+// Session is a puzzle being played
+type Session struct {
+	Game   game.Game
+	Puzzle Puzzle
+	stats  *game.Stats
+}
+
+// Start returns session playing the puzzle from its starting position
+func (puzzle Puzzle) Start(options ...game.Option) *Session {
+	stats := &game.Stats{}
+	g := puzzle.Game(append(options, game.WithObserver(stats))...)
+	return &Session{Game: g, Puzzle: puzzle, stats: stats}
+}
+
+// Move places the block at x,y position of the board
+func (session *Session) Move(block game.BlockType, x int, y int) error {
+	return session.Play(game.Move{Block: block, X: x, Y: y})
+}
+
+// Play makes the move in the game. Error is returned when the puzzle is
+// already solved or failed. Game over caused by the move solving the puzzle
+// is not reported as an error.
+func (session *Session) Play(move game.Move) error {
+	if status := session.Status(); status != InProgress {
+		return fmt.Errorf("Puzzle is %s", status)
+	}
+	err := session.Game.Play(move)
+	if errorGame, ok := err.(*game.ErrorGame); ok && errorGame.Reason == game.GameOver && session.Status() == Solved {
+		return nil
+	}
+	return err
+}
+
+// Progress returns the value compared with the target of the goal: lines
+// cleared, score or moves made
+func (session *Session) Progress() int {
+	return session.Puzzle.Goal.progress(session.Game.Score, session.stats.Moves, session.lines())
+}
+
+// Status returns Solved when the goal is reached and Failed when the game is
+// over or all blocks of the puzzle were placed without reaching the goal
+func (session *Session) Status() Status {
+	return session.Puzzle.status(session.Game, session.stats.Moves, session.lines())
+}
+
+// Solve returns moves reaching the goal from the current position, second
+// value is false when the goal cannot be reached
+func (session *Session) Solve() ([]game.Move, bool) {
+	solver := solver{puzzle: session.Puzzle, visited: make(map[string]bool)}
+	return solver.solve(session.Game.Clone(), session.stats.Moves, session.lines())
+}
+
+func (session *Session) lines() int {
+	return session.stats.RowsCleared + session.stats.ColumnsCleared
+}
+
+func (puzzle Puzzle) status(g game.Game, moves int, lines int) Status {
+	if puzzle.Goal.progress(g.Score, moves, lines) >= puzzle.Goal.Target {
+		return Solved
+	}
+	if g.GameOver || moves >= len(puzzle.Sequence) {
+		return Failed
+	}
+	return InProgress
+}
@@ -0,0 +1,41 @@
+package puzzle
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wrutkowski/go1010/game"
+)
+
+func TestSession(t *testing.T) {
+	assert := assert.New(t)
+
+	puzzle, _ := LoadPuzzle("testdata/cross.yaml")
+	session := puzzle.Start()
+	assert.Equal(InProgress, session.Status())
+
+	// row 2 and columns closed by stones are cleared
+	assert.Nil(session.Move(game.A, 2, 0))
+	assert.Equal(3, session.Progress())
+	assert.Equal(InProgress, session.Status())
+	assert.Equal(game.Stone, session.Game.Board[1][0], "stones stay on the board")
+
+	assert.Nil(session.Move(game.B, 0, 2))
+	assert.Equal(6, session.Progress())
+	assert.Equal(Solved, session.Status())
+	assert.NotNil(session.Move(game.C, 1, 1))
+}
+
+func TestSessionFailed(t *testing.T) {
+	assert := assert.New(t)
+
+	puzzle, _ := LoadPuzzle("testdata/impossible.json")
+	session := puzzle.Start()
+	assert.Nil(session.Move(game.A, 0, 0))
+	assert.Nil(session.Move(game.B, 0, 1))
+	assert.Equal(InProgress, session.Status())
+	assert.Nil(session.Move(game.C, 0, 2))
+	assert.Equal(0, session.Progress())
+	assert.Equal(Failed, session.Status(), "all blocks are used")
+	assert.Equal("failed", session.Status().String())
+}
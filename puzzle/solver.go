@@ -0,0 +1,51 @@
+package puzzle
+
+import (
+	"fmt"
+
+	"github.com/wrutkowski/go1010/game"
+)
+
+// Solve returns moves reaching the goal of the puzzle from its starting
+// position, second value is false when the puzzle cannot be solved
+func Solve(puzzle Puzzle) ([]game.Move, bool) {
+	return puzzle.Start().Solve()
+}
+
+// solver searches all sequences of moves depth first, positions already
+// checked are not visited again
+type solver struct {
+	puzzle  Puzzle
+	visited map[string]bool
+}
+
+func (solver *solver) solve(g game.Game, moves int, lines int) ([]game.Move, bool) {
+	switch solver.puzzle.status(g, moves, lines) {
+	case Solved:
+		return []game.Move{}, true
+	case Failed:
+		return nil, false
+	}
+
+	// the same position can be reached by placing blocks in different order
+	position := fmt.Sprintf("%s %d %d", g.Notation(), moves, lines)
+	if solver.visited[position] {
+		return nil, false
+	}
+	solver.visited[position] = true
+
+	for _, move := range append(g.LegalMoves(), g.LegalActions()...) {
+		next, result, err := g.Simulate(move)
+		if err != nil {
+			continue
+		}
+		nextMoves := moves
+		if move.Action == game.PlaceBlock {
+			nextMoves++
+		}
+		if solution, ok := solver.solve(next, nextMoves, lines+result.LinesCleared()); ok {
+			return append([]game.Move{move}, solution...), true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,48 @@
+package puzzle
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSolve(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, name := range []string{"testdata/cross.yaml", "testdata/pillars.yaml"} {
+		puzzle, err := LoadPuzzle(name)
+		assert.Nil(err)
+
+		solution, ok := Solve(puzzle)
+		assert.True(ok, name)
+
+		// solution solves the puzzle when played
+		session := puzzle.Start()
+		for _, move := range solution {
+			assert.Nil(session.Play(move))
+		}
+		assert.Equal(Solved, session.Status(), name)
+	}
+
+	puzzle, _ := LoadPuzzle("testdata/impossible.json")
+	_, ok := Solve(puzzle)
+	assert.False(ok)
+}
+
+func TestSolveFromPosition(t *testing.T) {
+	assert := assert.New(t)
+
+	puzzle, _ := LoadPuzzle("testdata/cross.yaml")
+	session := puzzle.Start()
+
+	// square two placed in the middle leaves no place for the other blocks
+	session.Move(2, 1, 1)
+	_, ok := session.Solve()
+	assert.False(ok)
+
+	session = puzzle.Start()
+	session.Move(0, 2, 0)
+	solution, ok := session.Solve()
+	assert.True(ok)
+	assert.Equal(1, len(solution))
+}
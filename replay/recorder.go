@@ -0,0 +1,40 @@
+package replay
+
+import (
+	"github.com/wrutkowski/go1010/game"
+)
+
+// Recorder plays the game and records every move made
+type Recorder struct {
+	Game   game.Game
+	replay Replay
+}
+
+// NewRecorder returns Recorder playing provided game
+func NewRecorder(g game.Game) *Recorder {
+	return &Recorder{Game: g, replay: New(g)}
+}
+
+// Move makes the move in the game and records it. Moves made in a game over
+// state are not recorded as they do not change the game.
+func (recorder *Recorder) Move(block game.BlockType, x int, y int) error {
+	return recorder.Play(game.Move{Block: block, X: x, Y: y})
+}
+
+// Play makes the move, placement or other action, in the game and records
+// it in the same way as Move
+func (recorder *Recorder) Play(move game.Move) error {
+	gameOver := recorder.Game.GameOver
+	err := recorder.Game.Play(move)
+	if !gameOver {
+		recorder.replay.Add(move, recorder.Game.Score)
+	}
+	return err
+}
+
+// Replay returns replay of all moves recorded so far
+func (recorder *Recorder) Replay() Replay {
+	replay := recorder.replay
+	replay.Moves = append([]game.Move(nil), recorder.replay.Moves...)
+	return replay
+}
@@ -0,0 +1,60 @@
+package replay
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wrutkowski/go1010/game"
+)
+
+func TestRecorder(t *testing.T) {
+	assert := assert.New(t)
+
+	g := game.New()
+	recorder := NewRecorder(g)
+
+	moves := recorder.Game.LegalMoves()
+	assert.Nil(recorder.Move(moves[0].Block, moves[0].X, moves[0].Y))
+
+	replay := recorder.Replay()
+	assert.Equal([]game.Move{moves[0]}, replay.Moves)
+	assert.Equal(recorder.Game.Score, replay.FinalScore)
+	assert.Equal(g.Board, replay.Start.Board)
+	assert.Equal(0, replay.Start.Score)
+
+	// moves in game over state are not recorded
+	recorder.Move(game.A, -1, -1)
+	recorder.Move(game.A, 0, 0)
+	assert.Equal(2, len(recorder.Replay().Moves))
+
+	// returned replay is not affected by further moves
+	assert.Equal(1, len(replay.Moves))
+}
+
+func TestRecorderActions(t *testing.T) {
+	assert := assert.New(t)
+
+	config := game.DefaultConfig()
+	config.Hold = true
+	config.Rotation = true
+	config.Rerolls = 1
+	recorder := NewRecorder(game.NewWithOptions(game.WithSeed(5), game.WithConfig(config)))
+
+	assert.Nil(recorder.Play(game.Move{Action: game.Reroll}))
+	assert.Nil(recorder.Play(game.Move{Block: game.B, Action: game.HoldBlock}))
+	for !recorder.Game.GameOver {
+		// when no block fits it can still be rotated or parked
+		moves := recorder.Game.LegalMoves()
+		if len(moves) == 0 {
+			moves = recorder.Game.LegalActions()
+		}
+		recorder.Play(moves[len(moves)-1])
+	}
+
+	replay := recorder.Replay()
+	assert.Equal(game.Reroll, replay.Moves[0].Action)
+	played, err := Play(replay)
+	assert.Nil(err)
+	assert.Equal(recorder.Game.Board, played.Board)
+	assert.Equal(recorder.Game.Held, played.Held)
+}
@@ -0,0 +1,80 @@
+package replay
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"time"
+
+	"github.com/wrutkowski/go1010/game"
+)
+
+// Replay records initial state of the game, including seed, rules and block
+// generator, and all moves made in the game
+type Replay struct {
+	Start      game.Game   `json:"start"`
+	Moves      []game.Move `json:"moves"`
+	FinalScore int         `json:"finalScore"`
+}
+
+// New returns Replay starting from a copy of provided game. Clock of the
+// copy is stopped, so that the time used before the start is kept.
+func New(start game.Game) Replay {
+	replay := Replay{Start: start.Clone(), FinalScore: start.Score}
+	replay.Start.SetClock(stoppedClock())
+	return replay
+}
+
+// Add appends the move to the replay together with the score after the move
+func (replay *Replay) Add(move game.Move, score int) {
+	replay.Moves = append(replay.Moves, move)
+	replay.FinalScore = score
+}
+
+// stoppedClock returns clock which does not move, times of moves are not
+// recorded, so the time limit is not checked again when the replay is played
+func stoppedClock() game.Clock {
+	return game.NewManualClock(time.Time{})
+}
+
+// startGame returns a copy of the start of the replay with a stopped clock,
+// start loaded from a file has the system clock again
+func startGame(replay Replay) game.Game {
+	g := replay.Start.Clone()
+	g.SetClock(stoppedClock())
+	return g
+}
+
+// Play re-executes all moves of the replay and returns the final state of
+// the game. Error is returned when the final score does not match the
+// recorded one.
+func Play(replay Replay) (game.Game, error) {
+	g := startGame(replay)
+	for _, move := range replay.Moves {
+		g.Play(move)
+	}
+	if g.Score != replay.FinalScore {
+		return g, fmt.Errorf("Final score %d does not match recorded score %d", g.Score, replay.FinalScore)
+	}
+	return g, nil
+}
+
+// SaveToFile writes the replay to JSON file with a given name
+func (replay Replay) SaveToFile(name string) error {
+	data, err := json.Marshal(replay)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(name, data, 0644)
+}
+
+// LoadFromFile reads the replay from JSON file with a given name
+func LoadFromFile(name string) (Replay, error) {
+	var replay Replay
+	data, err := ioutil.ReadFile(name)
+	if err != nil {
+		return replay, err
+	}
+	err = json.Unmarshal(data, &replay)
+	return replay, err
+}
@@ -0,0 +1,117 @@
+package replay
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wrutkowski/go1010/game"
+)
+
+// playedReplay plays the game until it is over always choosing the first
+// legal move and returns its replay
+func playedReplay(g game.Game) Replay {
+	recorder := NewRecorder(g)
+	for !recorder.Game.GameOver {
+		moves := recorder.Game.LegalMoves()
+		recorder.Move(moves[0].Block, moves[0].X, moves[0].Y)
+	}
+	return recorder.Replay()
+}
+
+func TestPlay(t *testing.T) {
+	assert := assert.New(t)
+
+	g := game.NewWithOptions(game.WithSeed(12), game.WithGenerator(game.NewBagGenerator()))
+	replay := playedReplay(g)
+
+	assert.NotEmpty(replay.Moves)
+	assert.True(replay.FinalScore > 0)
+
+	final, err := Play(replay)
+	assert.Nil(err)
+	assert.Equal(replay.FinalScore, final.Score)
+	assert.True(final.GameOver)
+
+	replay.FinalScore++
+	_, err = Play(replay)
+	assert.NotNil(err)
+}
+
+func TestPlayWithInvalidMoves(t *testing.T) {
+	assert := assert.New(t)
+
+	config := game.DefaultConfig()
+	config.InvalidMove = game.PenalizeInvalidMove
+	config.InvalidMovePenalty = 2
+	recorder := NewRecorder(game.NewWithOptions(game.WithConfig(config)))
+
+	recorder.Move(game.A, -1, 0)
+	recorder.Move(game.A, 0, 0)
+	recorder.Move(game.A, 0, 0)
+
+	final, err := Play(recorder.Replay())
+	assert.Nil(err)
+	assert.Equal(recorder.Game.Score, final.Score)
+	assert.Equal(3, len(recorder.Replay().Moves))
+}
+
+func TestPlayWithTimeLimit(t *testing.T) {
+	assert := assert.New(t)
+
+	config := game.DefaultConfig()
+	config.TimeLimit = time.Minute
+	clock := game.NewManualClock(time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC))
+	recorder := NewRecorder(game.NewWithOptions(game.WithSeed(3), game.WithConfig(config), game.WithClock(clock)))
+	for i := 0; i < 5 && !recorder.Game.GameOver; i++ {
+		moves := recorder.Game.LegalMoves()
+		assert.Nil(recorder.Move(moves[0].Block, moves[0].X, moves[0].Y))
+		clock.Advance(10 * time.Second)
+	}
+
+	// replay is played long after the time limit of the recorded game
+	clock.Advance(time.Hour)
+	final, err := Play(recorder.Replay())
+	assert.Nil(err)
+	assert.Equal(recorder.Game.Score, final.Score)
+	assert.Equal(recorder.Game.Board, final.Board)
+	assert.False(final.GameOver)
+
+	stepper := NewStepper(recorder.Replay())
+	assert.True(stepper.Seek(stepper.Len()))
+	assert.Equal(recorder.Game.Board, stepper.Game().Board)
+
+	data, err := json.Marshal(recorder.Replay())
+	assert.Nil(err)
+	var loaded Replay
+	assert.Nil(json.Unmarshal(data, &loaded))
+	final, err = Play(loaded)
+	assert.Nil(err)
+	assert.Equal(recorder.Game.Score, final.Score)
+}
+
+func TestSaveAndLoad(t *testing.T) {
+	assert := assert.New(t)
+
+	file, err := ioutil.TempFile("", "go1010_replay_")
+	assert.Nil(err)
+	defer os.Remove(file.Name())
+
+	replay := playedReplay(game.NewWithSeed(5))
+	assert.Nil(replay.SaveToFile(file.Name()))
+
+	loaded, err := LoadFromFile(file.Name())
+	assert.Nil(err)
+	assert.Equal(replay.Moves, loaded.Moves)
+	assert.Equal(replay.FinalScore, loaded.FinalScore)
+
+	final, err := Play(loaded)
+	assert.Nil(err)
+	assert.Equal(replay.FinalScore, final.Score)
+
+	_, err = LoadFromFile(file.Name() + "_missing")
+	assert.NotNil(err)
+}
@@ -0,0 +1,69 @@
+package replay
+
+import (
+	"github.com/wrutkowski/go1010/game"
+)
+
+// Stepper allows to go through the replay move by move in both directions
+type Stepper struct {
+	replay   Replay
+	states   []game.Game
+	position int
+}
+
+// NewStepper returns Stepper positioned at the start of the replay
+func NewStepper(replay Replay) *Stepper {
+	stepper := &Stepper{replay: replay}
+	g := startGame(replay)
+	stepper.states = append(stepper.states, g.Clone())
+	for _, move := range replay.Moves {
+		g.Play(move)
+		stepper.states = append(stepper.states, g.Clone())
+	}
+	return stepper
+}
+
+// Game returns state of the game at the current position
+func (stepper *Stepper) Game() game.Game {
+	return stepper.states[stepper.position]
+}
+
+// Position returns number of moves made until the current position
+func (stepper *Stepper) Position() int {
+	return stepper.position
+}
+
+// Len returns number of moves in the replay
+func (stepper *Stepper) Len() int {
+	return len(stepper.replay.Moves)
+}
+
+// LastMove returns the move which led to the current position, second value
+// is false at the start of the replay
+func (stepper *Stepper) LastMove() (game.Move, bool) {
+	if stepper.position == 0 {
+		return game.Move{}, false
+	}
+	return stepper.replay.Moves[stepper.position-1], true
+}
+
+// Next moves to the next position, returns false at the end of the replay
+func (stepper *Stepper) Next() bool {
+	return stepper.Seek(stepper.position + 1)
+}
+
+// Previous moves to the previous position, returns false at the start of
+// the replay
+func (stepper *Stepper) Previous() bool {
+	return stepper.Seek(stepper.position - 1)
+}
+
+// Seek moves to the position after a given number of moves, returns false
+// when the position is out of the replay
+func (stepper *Stepper) Seek(position int) bool {
+	if position < 0 || position >= len(stepper.states) {
+		return false
+	}
+	stepper.position = position
+	return true
+}
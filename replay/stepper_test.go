@@ -0,0 +1,37 @@
+package replay
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wrutkowski/go1010/game"
+)
+
+func TestStepper(t *testing.T) {
+	assert := assert.New(t)
+
+	replay := playedReplay(game.NewWithSeed(3))
+	stepper := NewStepper(replay)
+
+	assert.Equal(len(replay.Moves), stepper.Len())
+	assert.Equal(0, stepper.Position())
+	assert.Equal(0, stepper.Game().Score)
+	_, ok := stepper.LastMove()
+	assert.False(ok)
+	assert.False(stepper.Previous())
+
+	assert.True(stepper.Next())
+	move, ok := stepper.LastMove()
+	assert.True(ok)
+	assert.Equal(replay.Moves[0], move)
+	assert.True(stepper.Game().Score > 0)
+
+	assert.True(stepper.Seek(stepper.Len()))
+	assert.Equal(replay.FinalScore, stepper.Game().Score)
+	assert.True(stepper.Game().GameOver)
+	assert.False(stepper.Next())
+
+	assert.True(stepper.Previous())
+	assert.Equal(stepper.Len()-1, stepper.Position())
+	assert.False(stepper.Seek(-1))
+}